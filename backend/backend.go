@@ -15,7 +15,11 @@
 // Package backend implements Zero Knowledge Proof systems: it consumes circuit compiled with gnark/frontend.
 package backend
 
-import "github.com/consensys/gnark/constraint/solver"
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/constraint/solver"
+)
 
 // ID represent a unique ID for a proving scheme
 type ID uint16
@@ -53,13 +57,17 @@ type ProverOption func(*ProverConfig) error
 
 // ProverConfig is the configuration for the prover with the options applied.
 type ProverConfig struct {
-	SolverOpts []solver.Option
+	SolverOpts        []solver.Option
+	FFTProvider       FFTProvider
+	NbBlindingFactors int
+	QuotientDegree    int
+	ProofBinding      []byte
 }
 
 // NewProverConfig returns a default ProverConfig with given prover options opts
 // applied.
 func NewProverConfig(opts ...ProverOption) (ProverConfig, error) {
-	opt := ProverConfig{}
+	opt := ProverConfig{NbBlindingFactors: DefaultNbBlindingFactors}
 	for _, option := range opts {
 		if err := option(&opt); err != nil {
 			return ProverConfig{}, err
@@ -75,3 +83,109 @@ func WithSolverOptions(solverOpts ...solver.Option) ProverOption {
 		return nil
 	}
 }
+
+const (
+	// DefaultNbBlindingFactors is the number of random coefficients the PLONK
+	// prover adds by default to each of the wire polynomials l, r and o.
+	DefaultNbBlindingFactors = 2
+
+	// MaxNbBlindingFactors is the largest number of blinding coefficients
+	// supported by the PLONK prover. The SRS and the three parts of the
+	// quotient polynomial committed to in a proof are sized for it.
+	MaxNbBlindingFactors = 2
+)
+
+// ErrNbBlindingFactors is returned by WithBlindingFactors for a number of
+// blinding factors out of the supported range [0, MaxNbBlindingFactors].
+var ErrNbBlindingFactors = fmt.Errorf("the number of blinding factors must be between 0 and %d", MaxNbBlindingFactors)
+
+// WithBlindingFactors sets the number of random coefficients the PLONK prover
+// adds to each of the wire polynomials l, r and o: the prover adds Q(X)(Xᵐ-1)
+// to them, where Q is a random polynomial with n coefficients and m is the
+// size of the evaluation domain. n defaults to DefaultNbBlindingFactors, and
+// 0, 1 and 2 are the only supported values: larger ones are rejected with
+// ErrNbBlindingFactors, as they would need a larger SRS and a quotient split
+// in more parts, while the wire polynomials are opened at a single point, so
+// that 2 random coefficients already hide them. The proofs are verified by
+// the same verifying key whatever the number of blinding factors. With 0
+// blinding factors the proofs are not zero-knowledge. Otherwise, the prover
+// also blinds the BSB22 commitments to parts of the witness, such as the
+// commitments to the queries of the lookups.
+//
+// This option is ignored by the Groth16 prover.
+func WithBlindingFactors(n int) ProverOption {
+	return func(opt *ProverConfig) error {
+		if n < 0 || n > MaxNbBlindingFactors {
+			return fmt.Errorf("%w, got %d", ErrNbBlindingFactors, n)
+		}
+		opt.NbBlindingFactors = n
+		return nil
+	}
+}
+
+// WithQuotientDegree sets the extension factor of the domain on which the PLONK
+// prover evaluates the quotient polynomial: a coset of factor·n elements, where
+// n is the size of the domain of the circuit. factor must be a power of two,
+// and the prover returns an error if it is too small for the degree of the
+// gates of the circuit. By default, the prover uses the domain chosen by the
+// setup, 4 times larger than the domain of the circuit (8 times for circuits
+// with less than 6 constraints).
+//
+// With a factor different from the one of the proving key, the prover
+// evaluates the polynomials of the circuit on the new domain for each proof.
+// The proofs are verified by the same verifying key, and the SRS required by
+// the setup is unchanged: it only depends on the size of the circuit.
+//
+// This option is ignored by the Groth16 prover.
+func WithQuotientDegree(factor int) ProverOption {
+	return func(opt *ProverConfig) error {
+		if factor <= 0 || factor&(factor-1) != 0 {
+			return fmt.Errorf("invalid quotient degree %d, must be a power of two", factor)
+		}
+		opt.QuotientDegree = factor
+		return nil
+	}
+}
+
+// WithFFTProvider specifies an external implementation of the number theoretic
+// transforms performed by the prover. When not set, the prover uses the
+// built-in FFT of gnark-crypto.
+func WithFFTProvider(p FFTProvider) ProverOption {
+	return func(opt *ProverConfig) error {
+		opt.FFTProvider = p
+		return nil
+	}
+}
+
+// WithProofBinding binds the PLONK proof to tag, for instance a chain ID and a
+// contract address, to prevent its replay in another context: the tag is fed
+// to the Fiat-Shamir transcript along with the public inputs, so the proof
+// only verifies with plonk.VerifyWithBinding and the same tag. An empty tag
+// binds nothing.
+//
+// The verifier exported with ExportSolidity doesn't support bound proofs.
+//
+// This option is ignored by the Groth16 prover.
+func WithProofBinding(tag []byte) ProverOption {
+	return func(opt *ProverConfig) error {
+		opt.ProofBinding = tag
+		return nil
+	}
+}
+
+// FFTProvider performs number theoretic transforms over the scalar field on
+// behalf of the prover, for instance to offload them to a hardware accelerator.
+//
+// The vectors passed to the provider are of the curve-specific fr.Vector type
+// (e.g. github.com/consensys/gnark-crypto/ecc/bn254/fr.Vector), in regular
+// (natural) order, and have a power of two size n. They must be transformed in
+// place over the n-th roots of unity returned by fft.NewDomain(n), or over
+// their coset shifted by the multiplicative generator of the field when coset
+// is set.
+type FFTProvider interface {
+	// FFT maps the coefficients of a polynomial to its evaluations.
+	FFT(v any, coset bool)
+
+	// FFTInverse maps the evaluations of a polynomial to its coefficients.
+	FFTInverse(v any, coset bool)
+}
@@ -148,3 +148,36 @@ func TestOneSecretOnePublicCommitted(t *testing.T) {
 		Two: 2,
 	})
 }
+
+func TestCommittedIndices(t *testing.T) {
+	_, _, vk := setup(t, &oneSecretOnePublicCommittedCircuit{})
+	assert.Equal(t, []int{1}, vk.CommittedIndices())
+
+	_, _, vk = setup(t, &noCommitmentCircuit{})
+	assert.Empty(t, vk.CommittedIndices())
+
+	_, _, vk = setup(t, &committedCommitmentCircuit{})
+	assert.Equal(t, []int{1, 2}, vk.CommittedIndices())
+}
+
+type committedCommitmentCircuit struct {
+	X, Y frontend.Variable `gnark:",public"`
+}
+
+func (c *committedCommitmentCircuit) Define(api frontend.API) error {
+	commitCompiler, ok := api.Compiler().(frontend.Committer)
+	if !ok {
+		return fmt.Errorf("compiler does not commit")
+	}
+	c0, err := commitCompiler.Commit(c.X)
+	if err != nil {
+		return err
+	}
+	// the second commitment covers the output of the first one
+	c1, err := commitCompiler.Commit(c0, c.Y)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(c1, c.Y)
+	return nil
+}
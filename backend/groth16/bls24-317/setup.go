@@ -28,6 +28,7 @@ import (
 	cs "github.com/consensys/gnark/constraint/bls24-317"
 	"math/big"
 	"math/bits"
+	"sort"
 )
 
 // ProvingKey is used by a Groth16 prover to encode a proof of a statement
@@ -654,6 +655,28 @@ func (vk *VerifyingKey) NbPublicWitness() int {
 	return (len(vk.G1.K) - 1)
 }
 
+// CommittedIndices returns the sorted indexes of the public wires committed to by
+// the commitments of the circuit. As in the full witness, index 0 is the
+// constant ONE_WIRE, so the i-th public wire corresponds to the (i-1)-th entry
+// of the public witness. The outputs of commitments committed to by other
+// commitments aren't public wires and are left out.
+func (vk *VerifyingKey) CommittedIndices() []int {
+	// vk.G1.K also holds the bases of the commitment outputs
+	nbPublic := len(vk.G1.K) - len(vk.PublicAndCommitmentCommitted)
+	seen := make(map[int]struct{})
+	res := make([]int, 0)
+	for i := range vk.PublicAndCommitmentCommitted {
+		for _, j := range vk.PublicAndCommitmentCommitted[i] {
+			if _, ok := seen[j]; !ok && j < nbPublic {
+				seen[j] = struct{}{}
+				res = append(res, j)
+			}
+		}
+	}
+	sort.Ints(res)
+	return res
+}
+
 // NbG1 returns the number of G1 elements in the VerifyingKey
 func (vk *VerifyingKey) NbG1() int {
 	return 3 + len(vk.G1.K)
@@ -101,6 +101,10 @@ type VerifyingKey interface {
 	// NbG2 returns the number of G2 elements in the VerifyingKey
 	NbG2() int
 
+	// CommittedIndices returns the sorted indexes of the public wires covered
+	// by the commitments, index 0 being the constant ONE_WIRE
+	CommittedIndices() []int
+
 	// ExportSolidity writes a solidity Verifier contract from the VerifyingKey
 	// this will return an error if not supported on the CurveID()
 	ExportSolidity(w io.Writer) error
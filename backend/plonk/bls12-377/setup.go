@@ -18,6 +18,7 @@ package plonk
 
 import (
 	"errors"
+	"fmt"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
@@ -188,6 +189,55 @@ func Setup(spr *cs.SparseR1CS, kzgSrs kzg.SRS) (*ProvingKey, *VerifyingKey, erro
 	return &pk, &vk, nil
 }
 
+// UpdateVK returns the verifying key of spr, a modified version of the circuit
+// of vk, without running the full setup. It is only valid when spr has the
+// same domain as vk, that is when its number of constraints plus its number of
+// public inputs rounds up to the same power of two, and when kzgSrs is the SRS
+// vk was computed with. It returns an error otherwise, in which case Setup must
+// be run again.
+//
+// The domain and the KZG verifying key are reused from vk. The commitments to
+// the selectors and to the permutation, which depend on the constraints, are
+// recomputed: they are the only part of the setup spent on the verifying key,
+// the proving key (and its evaluations on the large domain) isn't built. The
+// proving key of vk is not valid for spr: proofs for spr need the proving key
+// returned by Setup.
+func UpdateVK(vk *VerifyingKey, spr *cs.SparseR1CS, kzgSrs kzg.SRS) (*VerifyingKey, error) {
+	sizeSystem := uint64(spr.GetNbConstraints() + len(spr.Public))
+	if size := ecc.NextPowerOfTwo(sizeSystem); size != vk.Size {
+		return nil, fmt.Errorf("the circuit has a domain of size %d, the verifying key of size %d", size, vk.Size)
+	}
+	if kzgSrs.Vk != vk.Kzg {
+		return nil, errors.New("the kzg srs is not the one of the verifying key")
+	}
+	if len(kzgSrs.Pk.G1) < int(vk.Size) {
+		return nil, errors.New("kzg srs is too small")
+	}
+
+	res := *vk
+	res.NbPublicVariables = uint64(len(spr.Public))
+	res.CommitmentConstraintIndexes = internal.IntSliceToUint64Slice(spr.CommitmentInfo.CommitmentIndexes())
+
+	// the proving key only holds what commitTrace needs
+	var pk ProvingKey
+	pk.Vk = &res
+	pk.Domain[0] = *fft.NewDomain(sizeSystem)
+	pk.Kzg = kzgSrs.Pk
+
+	BuildTrace(spr, &pk.trace)
+	nbVariables := spr.NbInternalVariables + len(spr.Public) + len(spr.Secret)
+	buildPermutation(spr, &pk.trace, nbVariables)
+	s := computePermutationPolynomials(&pk.trace, &pk.Domain[0])
+	pk.trace.S1 = s[0]
+	pk.trace.S2 = s[1]
+	pk.trace.S3 = s[2]
+
+	if err := commitTrace(&pk.trace, &pk); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 // computeLagrangeCosetPolys computes each polynomial except qk in Lagrange coset
 // basis. Qk will be evaluated in Lagrange coset basis once it is completed by the prover.
 func (pk *ProvingKey) computeLagrangeCosetPolys() {
@@ -255,6 +305,17 @@ func (vk *VerifyingKey) NbPublicWitness() int {
 	return int(vk.NbPublicVariables)
 }
 
+// PermutationCommitments returns the commitments to the permutation polynomials
+// S₁, S₂, S₃, in compressed form.
+func (vk *VerifyingKey) PermutationCommitments() [][]byte {
+	res := make([][]byte, len(vk.S))
+	for i := range vk.S {
+		b := vk.S[i].Bytes()
+		res[i] = b[:]
+	}
+	return res
+}
+
 // VerifyingKey returns pk.Vk
 func (pk *ProvingKey) VerifyingKey() interface{} {
 	return pk.Vk
@@ -370,15 +431,38 @@ func (pk *ProvingKey) initDomains(spr *cs.SparseR1CS) {
 
 	// h, the quotient polynomial is of degree 3(n+1)+2, so it's in a 3(n+2) dim vector space,
 	// the domain is the next power of 2 superior to 3(n+2). 4*domainNum is enough in all cases
-	// except when n<6.
+	// except when n<6. The permutation argument is of degree 4 in the wires and
+	// their selectors, a gate of degree d with its selector is of degree d+1.
+	factor := ecc.NextPowerOfTwo(uint64(spr.MaxDegree() + 1))
+	if factor < 4 {
+		factor = 4
+	}
 	if sizeSystem < 6 {
-		pk.Domain[1] = *fft.NewDomain(8 * sizeSystem)
-	} else {
-		pk.Domain[1] = *fft.NewDomain(4 * sizeSystem)
+		factor *= 2
 	}
+	pk.Domain[1] = *fft.NewDomain(factor * sizeSystem)
 
 }
 
+// withQuotientDegree returns a shallow copy of pk whose big domain is factor
+// times larger than the small one, or pk itself if its big domain already is.
+// It returns an error if the big domain is too small to compute h, the quotient
+// polynomial, of degree 3(n+2)-1.
+func (pk *ProvingKey) withQuotientDegree(factor int) (*ProvingKey, error) {
+	n := pk.Domain[0].Cardinality
+	size := uint64(factor) * n
+	if size == pk.Domain[1].Cardinality {
+		return pk, nil
+	}
+	if size < 3*(n+2) {
+		return nil, fmt.Errorf("quotient degree %d is too small for a domain of size %d", factor, n)
+	}
+	res := *pk
+	res.Domain[1] = *fft.NewDomain(size)
+	res.computeLagrangeCosetPolys()
+	return &res, nil
+}
+
 // buildPermutation builds the Permutation associated with a circuit.
 //
 // The permutation s is composed of cycles of maximum length such that
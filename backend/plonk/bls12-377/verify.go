@@ -36,15 +36,106 @@ import (
 
 var (
 	errWrongClaimedQuotient = errors.New("claimed quotient is not as expected")
+	errDomainSizeMismatch   = errors.New("the proof is for a domain size different from the verifying key's")
+	errMockProof            = errors.New("mock proof: produced by MockProve, not verifiable")
 )
 
 func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) error {
+	return verify(proof, vk, publicWitness, nil)
+}
+
+// VerifyWithBinding verifies a proof bound to tag with the
+// backend.WithProofBinding prover option. The verification fails if the proof
+// was bound to a different tag, or wasn't bound to any. An empty tag is the
+// same as no binding, VerifyWithBinding then behaves as Verify.
+func VerifyWithBinding(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, tag []byte) error {
+	return verify(proof, vk, publicWitness, tag)
+}
+
+func verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, tag []byte) error {
 	log := logger.Logger().With().Str("curve", "bls12-377").Str("backend", "plonk").Logger()
 	start := time.Now()
 
+	claims, err := reduceToOpeningClaims(proof, vk, publicWitness, tag, nil)
+	if err != nil {
+		return err
+	}
+
+	// Batch verify
+	err = kzg.BatchVerifyMultiPoints(claims.digests[:], claims.proofs[:], claims.points[:], vk.Kzg)
+
+	log.Debug().Dur("took", time.Since(start)).Msg("verifier done")
+
+	return err
+}
+
+// VerifyStats reports the time spent in each phase of the verification of a
+// proof.
+type VerifyStats struct {
+	// Validation checks the shape of the proof against the verifying key. The
+	// points of the proof are checked when it is read.
+	Validation time.Duration
+	// Challenges binds the public data and derives the Fiat-Shamir challenges.
+	Challenges time.Duration
+	// MSM checks the claimed quotient and folds the commitments into the KZG
+	// opening claims, which is dominated by the multi-scalar multiplications.
+	MSM time.Duration
+	// Pairing verifies the opening claims with a pairing check.
+	Pairing time.Duration
+}
+
+// VerifyInstrumented verifies proof as Verify does, and returns the time spent
+// in each phase of the verification. The stats of the phases performed are
+// returned even if the verification fails.
+func VerifyInstrumented(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) (VerifyStats, error) {
+	var stats VerifyStats
+	claims, err := reduceToOpeningClaims(proof, vk, publicWitness, nil, &stats)
+	if err != nil {
+		return stats, err
+	}
+
+	start := time.Now()
+	err = kzg.BatchVerifyMultiPoints(claims.digests[:], claims.proofs[:], claims.points[:], vk.Kzg)
+	stats.Pairing = time.Since(start)
+
+	return stats, err
+}
+
+// openingClaims are the KZG openings a PLONK proof reduces to, once the
+// algebraic relation between the claimed values has been checked.
+type openingClaims struct {
+	digests [2]kzg.Digest
+	proofs  [2]kzg.OpeningProof
+	points  [2]fr.Element
+}
+
+// reduceToOpeningClaims performs all the verifier checks but the pairings, and
+// returns the KZG openings which remain to be verified. The proof is expected
+// to be bound to tag, if not empty. When stats is not nil, the time spent in
+// each phase is recorded in it.
+func reduceToOpeningClaims(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, tag []byte, stats *VerifyStats) (*openingClaims, error) {
+	if stats == nil {
+		stats = new(VerifyStats)
+	}
+	lap := time.Now()
+	record := func(d *time.Duration) {
+		now := time.Now()
+		*d = now.Sub(lap)
+		lap = now
+	}
+
+	if proof.Mock {
+		return nil, errMockProof
+	}
+
+	if proof.DomainSize != 0 && proof.DomainSize != vk.Size {
+		return nil, errDomainSizeMismatch
+	}
+
 	if len(proof.Bsb22Commitments) != len(vk.Qcp) {
-		return errors.New("BSB22 Commitment number mismatch")
+		return nil, errors.New("BSB22 Commitment number mismatch")
 	}
+	record(&stats.Validation)
 
 	// pick a hash function to derive the challenge (the same as in the prover)
 	hFunc := sha256.New()
@@ -55,18 +146,18 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) error {
 	// The first challenge is derived using the public data: the commitments to the permutation,
 	// the coefficients of the circuit, and the public inputs.
 	// derive gamma from the Comm(blinded cl), Comm(blinded cr), Comm(blinded co)
-	if err := bindPublicData(&fs, "gamma", vk, publicWitness); err != nil {
-		return err
+	if err := bindPublicData(&fs, "gamma", vk, publicWitness, tag); err != nil {
+		return nil, err
 	}
 	gamma, err := deriveRandomness(&fs, "gamma", &proof.LRO[0], &proof.LRO[1], &proof.LRO[2])
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// derive beta from Comm(l), Comm(r), Comm(o)
 	beta, err := deriveRandomness(&fs, "beta")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// derive alpha from Comm(l), Comm(r), Comm(o), Com(Z), Bsb22Commitments
@@ -77,14 +168,15 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) error {
 	alphaDeps[len(alphaDeps)-1] = &proof.Z
 	alpha, err := deriveRandomness(&fs, "alpha", alphaDeps...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// derive zeta, the point of evaluation
 	zeta, err := deriveRandomness(&fs, "zeta", &proof.H[0], &proof.H[1], &proof.H[2])
 	if err != nil {
-		return err
+		return nil, err
 	}
+	record(&stats.Challenges)
 
 	// evaluation of Z=Xⁿ⁻¹ at ζ
 	var zetaPowerM, zzeta fr.Element
@@ -122,7 +214,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) error {
 		for i := range vk.CommitmentConstraintIndexes {
 			var hashRes []fr.Element
 			if hashRes, err = fr.Hash(proof.Bsb22Commitments[i].Marshal(), []byte("BSB22-Plonk"), 1); err != nil {
-				return err
+				return nil, err
 			}
 
 			// Computing L_{CommitmentIndex}
@@ -178,7 +270,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) error {
 
 	// check that H(ζ) is as claimed
 	if !claimedQuotient.Equal(&linearizedPolynomialZeta) {
-		return errWrongClaimedQuotient
+		return nil, errWrongClaimedQuotient
 	}
 
 	// compute the folded commitment to H: Comm(h₁) + ζᵐ⁺²*Comm(h₂) + ζ²⁽ᵐ⁺²⁾*Comm(h₃)
@@ -233,7 +325,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) error {
 		_s1, _s2, // second & third part
 	)
 	if _, err := linearizedPolynomialDigest.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Fold the first proof
@@ -253,33 +345,164 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) error {
 		hFunc,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Batch verify
+	record(&stats.MSM)
+
+	// the proof is valid iff both openings are
 	var shiftedZeta fr.Element
 	shiftedZeta.Mul(&zeta, &vk.Generator)
-	err = kzg.BatchVerifyMultiPoints([]kzg.Digest{
-		foldedDigest,
-		proof.Z,
-	},
-		[]kzg.OpeningProof{
-			foldedProof,
-			proof.ZShiftedOpening,
-		},
-		[]fr.Element{
-			zeta,
-			shiftedZeta,
-		},
-		vk.Kzg,
-	)
+	return &openingClaims{
+		digests: [2]kzg.Digest{foldedDigest, proof.Z},
+		proofs:  [2]kzg.OpeningProof{foldedProof, proof.ZShiftedOpening},
+		points:  [2]fr.Element{zeta, shiftedZeta},
+	}, nil
+}
 
-	log.Debug().Dur("took", time.Since(start)).Msg("verifier done")
+// BatchVerifier verifies a stream of proofs against the same verifying key,
+// without keeping them in memory. Each proof added is checked up to the
+// pairings, and its KZG openings are folded with fresh random coefficients into
+// a running accumulator. Verify then performs a single pairing check for all
+// the proofs added so far.
+//
+// A BatchVerifier is not safe for concurrent use.
+type BatchVerifier struct {
+	vk *VerifyingKey
 
-	return err
+	// ∑ᵢλᵢ([fᵢ(α)]G₁ - [fᵢ(pᵢ)]G₁ + pᵢ[Hᵢ(α)]G₁)
+	foldedDigests curve.G1Affine
+
+	// ∑ᵢλᵢ[Hᵢ(α)]G₁
+	foldedQuotients curve.G1Affine
+
+	nbProofs int
+}
+
+// NewBatchVerifier returns a BatchVerifier for proofs of the circuit described
+// by vk.
+func NewBatchVerifier(vk *VerifyingKey) *BatchVerifier {
+	return &BatchVerifier{vk: vk}
+}
+
+// Add checks proof against publicWitness up to the pairings, and folds its
+// openings into the accumulator. An error is returned if the proof is
+// already known to be invalid, or if the random coefficients of the folding
+// can't be drawn. In both cases, the accumulator is unchanged.
+func (bv *BatchVerifier) Add(proof *Proof, publicWitness fr.Vector) error {
+	claims, err := reduceToOpeningClaims(proof, bv.vk, publicWitness, nil, nil)
+	if err != nil {
+		return err
+	}
+	return bv.addClaims(claims)
+}
+
+// addClaims folds the opening claims of a proof into the accumulator, with
+// random coefficients. The claims are folded into copies of the accumulator,
+// which replace it only once all of them are, so that it is unchanged on error.
+func (bv *BatchVerifier) addClaims(claims *openingClaims) error {
+	foldedDigests, foldedQuotients := bv.foldedDigests, bv.foldedQuotients
+	for i := range claims.digests {
+		var lambda, minusLambdaEval fr.Element
+		if _, err := lambda.SetRandom(); err != nil {
+			return err
+		}
+		var bLambda big.Int
+		lambda.BigInt(&bLambda)
+
+		// λ[H(α)]G₁
+		var quotient curve.G1Affine
+		quotient.ScalarMultiplication(&claims.proofs[i].H, &bLambda)
+
+		// λ[f(α)]G₁ - λ[f(p)]G₁ + p(λ[H(α)]G₁)
+		minusLambdaEval.Mul(&lambda, &claims.proofs[i].ClaimedValue).Neg(&minusLambdaEval)
+		var digest curve.G1Affine
+		if _, err := digest.MultiExp(
+			[]curve.G1Affine{claims.digests[i], bv.vk.Kzg.G1, quotient},
+			[]fr.Element{lambda, minusLambdaEval, claims.points[i]},
+			ecc.MultiExpConfig{},
+		); err != nil {
+			return err
+		}
+
+		foldedDigests.Add(&foldedDigests, &digest)
+		foldedQuotients.Add(&foldedQuotients, &quotient)
+	}
+	bv.foldedDigests, bv.foldedQuotients = foldedDigests, foldedQuotients
+	bv.nbProofs++
+
+	return nil
+}
+
+// Verify checks all the proofs added so far with a single pairing check. It
+// does not reset the accumulator, so that more proofs may be added and the
+// whole batch verified again.
+func (bv *BatchVerifier) Verify() error {
+	if bv.nbProofs == 0 {
+		return errors.New("no proof to verify")
+	}
+
+	return FinishVerify(bv.pairingInputs())
+}
+
+// pairingInputs returns the pairs of points of the pairing check of the
+// accumulated proofs:
+//
+//	e(∑ᵢλᵢ([fᵢ(α)]G₁ - [fᵢ(pᵢ)]G₁ + pᵢ[Hᵢ(α)]G₁), G₂).e(-∑ᵢλᵢ[Hᵢ(α)]G₁, [α]G₂) == 1
+func (bv *BatchVerifier) pairingInputs() *PairingInputs {
+	var foldedQuotients curve.G1Affine
+	foldedQuotients.Neg(&bv.foldedQuotients)
+	return &PairingInputs{
+		G1: []curve.G1Affine{bv.foldedDigests, foldedQuotients},
+		G2: []curve.G2Affine{bv.vk.Kzg.G2[0], bv.vk.Kzg.G2[1]},
+	}
+}
+
+// PairingInputs are the pairs of points (G1[i], G2[i]) whose product of
+// pairings ∏ᵢe(G1[i], G2[i]) must be the identity of the target group for a
+// proof to be valid.
+type PairingInputs struct {
+	G1 []curve.G1Affine
+	G2 []curve.G2Affine
+}
+
+// Curve returns the curve of the points.
+func (inputs *PairingInputs) Curve() ecc.ID {
+	return ecc.BLS12_377
+}
+
+// PrepareVerify performs all the checks of Verify but the pairings, and
+// returns the inputs of the final pairing check, so that it can be completed
+// by an external component. The proof is valid if and only if the check
+// succeeds, which FinishVerify performs.
+//
+// The KZG openings of the proof are folded with random coefficients, so the
+// inputs differ from one call to the other.
+func PrepareVerify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector) (*PairingInputs, error) {
+	bv := NewBatchVerifier(vk)
+	if err := bv.Add(proof, publicWitness); err != nil {
+		return nil, err
+	}
+	return bv.pairingInputs(), nil
+}
+
+// FinishVerify performs the pairing check prepared by PrepareVerify. It
+// returns kzg.ErrVerifyOpeningProof if the check fails.
+func FinishVerify(inputs *PairingInputs) error {
+	check, err := curve.PairingCheck(inputs.G1, inputs.G2)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return kzg.ErrVerifyOpeningProof
+	}
+	return nil
 }
 
-func bindPublicData(fs *fiatshamir.Transcript, challenge string, vk *VerifyingKey, publicInputs []fr.Element) error {
+// bindPublicData binds the verifying key and the public inputs to the
+// challenge, followed by the binding tag of the proof if not empty. Without a
+// tag, the transcript is the one of the exported Solidity verifier.
+func bindPublicData(fs *fiatshamir.Transcript, challenge string, vk *VerifyingKey, publicInputs []fr.Element, tag []byte) error {
 
 	// permutation
 	if err := fs.Bind(challenge, vk.S[0].Marshal()); err != nil {
@@ -321,6 +544,13 @@ func bindPublicData(fs *fiatshamir.Transcript, challenge string, vk *VerifyingKe
 		}
 	}
 
+	// binding tag
+	if len(tag) != 0 {
+		if err := fs.Bind(challenge, tag); err != nil {
+			return err
+		}
+	}
+
 	return nil
 
 }
@@ -349,3 +579,8 @@ func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*cu
 func (vk *VerifyingKey) ExportSolidity(w io.Writer) error {
 	return errors.New("not implemented")
 }
+
+// ExportSolidityNamed not implemented for BLS12-377
+func (vk *VerifyingKey) ExportSolidityNamed(w io.Writer, names []string) error {
+	return errors.New("not implemented")
+}
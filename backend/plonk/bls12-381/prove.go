@@ -19,6 +19,7 @@ package plonk
 import (
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"math/big"
 	"runtime"
 	"sync"
@@ -63,10 +64,66 @@ type Proof struct {
 
 	// Opening proof of Z at zeta*mu
 	ZShiftedOpening kzg.OpeningProof
+
+	// DomainSize is the size of the evaluation domain of the circuit, checked
+	// against the verifying key. 0 if unknown, for proofs in the legacy format.
+	DomainSize uint64
+
+	// Mock is set for the placeholder proofs produced by MockProve. It is
+	// serialized in the format tag, and such proofs are always rejected by
+	// Verify.
+	Mock bool
+}
+
+// Equal reports whether proof and other have the same commitments, opening
+// proofs and claimed values. DomainSize and Mock, which are only metadata
+// carried by the versioned encoding, are ignored.
+func (proof *Proof) Equal(other *Proof) bool {
+	g1Equal := func(a, b []kzg.Digest) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !a[i].Equal(&b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if !g1Equal(proof.LRO[:], other.LRO[:]) ||
+		!proof.Z.Equal(&other.Z) ||
+		!g1Equal(proof.H[:], other.H[:]) ||
+		!g1Equal(proof.Bsb22Commitments, other.Bsb22Commitments) {
+		return false
+	}
+
+	if !proof.BatchedProof.H.Equal(&other.BatchedProof.H) ||
+		len(proof.BatchedProof.ClaimedValues) != len(other.BatchedProof.ClaimedValues) {
+		return false
+	}
+	for i := range proof.BatchedProof.ClaimedValues {
+		if !proof.BatchedProof.ClaimedValues[i].Equal(&other.BatchedProof.ClaimedValues[i]) {
+			return false
+		}
+	}
+
+	return proof.ZShiftedOpening.H.Equal(&other.ZShiftedOpening.H) &&
+		proof.ZShiftedOpening.ClaimedValue.Equal(&other.ZShiftedOpening.ClaimedValue)
+}
+
+// PermutationEval returns the claimed value of the permutation polynomial Z at
+// ζω, in big-endian form.
+func (proof *Proof) PermutationEval() []byte {
+	b := proof.ZShiftedOpening.ClaimedValue.Bytes()
+	return b[:]
 }
 
 // Computing and verifying Bsb22 multi-commits explained in https://hackmd.io/x8KsadW3RRyX7YTCFJIkHg
-func bsb22ComputeCommitmentHint(spr *cs.SparseR1CS, pk *ProvingKey, proof *Proof, cCommitments []*iop.Polynomial, res *fr.Element, commDepth int) solver.Hint {
+//
+// When blind is set, the committed values are blinded with random values at two
+// positions where qcp is zero, so that the commitment doesn't reveal the
+// committed part of the witness (the queries of the lookups, for example).
+func bsb22ComputeCommitmentHint(spr *cs.SparseR1CS, pk *ProvingKey, proof *Proof, cCommitments []*iop.Polynomial, res *fr.Element, commDepth int, fftProvider backend.FFTProvider, blind bool) solver.Hint {
 	return func(_ *big.Int, ins, outs []*big.Int) error {
 		commitmentInfo := spr.CommitmentInfo.(constraint.PlonkCommitments)[commDepth]
 		committedValues := make([]fr.Element, pk.Domain[0].Cardinality)
@@ -78,15 +135,17 @@ func bsb22ComputeCommitmentHint(spr *cs.SparseR1CS, pk *ProvingKey, proof *Proof
 			err     error
 			hashRes []fr.Element
 		)
-		if _, err = committedValues[offset+commitmentInfo.CommitmentIndex].SetRandom(); err != nil { // Commitment injection constraint has qcp = 0. Safe to use for blinding.
-			return err
-		}
-		if _, err = committedValues[offset+spr.GetNbConstraints()-1].SetRandom(); err != nil { // Last constraint has qcp = 0. Safe to use for blinding
-			return err
+		if blind {
+			if _, err = committedValues[offset+commitmentInfo.CommitmentIndex].SetRandom(); err != nil { // Commitment injection constraint has qcp = 0. Safe to use for blinding.
+				return err
+			}
+			if _, err = committedValues[offset+spr.GetNbConstraints()-1].SetRandom(); err != nil { // Last constraint has qcp = 0. Safe to use for blinding
+				return err
+			}
 		}
 		pi2iop := iop.NewPolynomial(&committedValues, iop.Form{Basis: iop.Lagrange, Layout: iop.Regular})
 		cCommitments[commDepth] = pi2iop.ShallowClone()
-		cCommitments[commDepth].ToCanonical(&pk.Domain[0]).ToRegular()
+		toBasis(cCommitments[commDepth], iop.Canonical, &pk.Domain[0], fftProvider).ToRegular()
 		if proof.Bsb22Commitments[commDepth], err = kzg.Commit(cCommitments[commDepth].Coefficients(), pk.Kzg); err != nil {
 			return err
 		}
@@ -111,6 +170,11 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	if err != nil {
 		return nil, err
 	}
+	if opt.QuotientDegree != 0 {
+		if pk, err = pk.withQuotientDegree(opt.QuotientDegree); err != nil {
+			return nil, err
+		}
+	}
 
 	start := time.Now()
 
@@ -121,7 +185,7 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	fs := fiatshamir.NewTranscript(hFunc, "gamma", "beta", "alpha", "zeta")
 
 	// result
-	proof := &Proof{}
+	proof := &Proof{DomainSize: pk.Domain[0].Cardinality}
 
 	commitmentInfo := spr.CommitmentInfo.(constraint.PlonkCommitments)
 	commitmentVal := make([]fr.Element, len(commitmentInfo)) // TODO @Tabaie get rid of this
@@ -131,7 +195,7 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	// override the hint for the commitment constraints
 	for i := range commitmentInfo {
 		opt.SolverOpts = append(opt.SolverOpts, solver.OverrideHint(commitmentInfo[i].HintID,
-			bsb22ComputeCommitmentHint(spr, pk, proof, cCommitments, &commitmentVal[i], i)))
+			bsb22ComputeCommitmentHint(spr, pk, proof, cCommitments, &commitmentVal[i], i, opt.FFTProvider, opt.NbBlindingFactors > 0)))
 	}
 
 	// override the hint for GKR constraints
@@ -161,25 +225,25 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	chLcc := make(chan struct{}, 1)
 	go func() {
 		for i := range cCommitments {
-			lcCommitments[i] = cCommitments[i].Clone(int(pk.Domain[1].Cardinality)).ToLagrangeCoset(&pk.Domain[1]).ToRegular() // lagrange coset form
+			lcCommitments[i] = toBasis(cCommitments[i].Clone(int(pk.Domain[1].Cardinality)), iop.LagrangeCoset, &pk.Domain[1], opt.FFTProvider).ToRegular() // lagrange coset form
 		}
 		close(chLcc)
 	}()
 
-	// l, r, o and blinded versions
+	// l, r, o and blinded versions, with opt.NbBlindingFactors random coefficients
 	var bwliop, bwriop, bwoiop *iop.Polynomial
 	var wgLRO sync.WaitGroup
 	wgLRO.Add(3)
 	go func() {
-		bwliop = wliop.Clone(int(pk.Domain[0].Cardinality) + 2).ToCanonical(&pk.Domain[0]).ToRegular().Blind(1)
+		bwliop = blind(toBasis(wliop.Clone(int(pk.Domain[0].Cardinality)+2), iop.Canonical, &pk.Domain[0], opt.FFTProvider).ToRegular(), opt.NbBlindingFactors)
 		wgLRO.Done()
 	}()
 	go func() {
-		bwriop = wriop.Clone(int(pk.Domain[0].Cardinality) + 2).ToCanonical(&pk.Domain[0]).ToRegular().Blind(1)
+		bwriop = blind(toBasis(wriop.Clone(int(pk.Domain[0].Cardinality)+2), iop.Canonical, &pk.Domain[0], opt.FFTProvider).ToRegular(), opt.NbBlindingFactors)
 		wgLRO.Done()
 	}()
 	go func() {
-		bwoiop = woiop.Clone(int(pk.Domain[0].Cardinality) + 2).ToCanonical(&pk.Domain[0]).ToRegular().Blind(1)
+		bwoiop = blind(toBasis(woiop.Clone(int(pk.Domain[0].Cardinality)+2), iop.Canonical, &pk.Domain[0], opt.FFTProvider).ToRegular(), opt.NbBlindingFactors)
 		wgLRO.Done()
 	}()
 
@@ -193,21 +257,21 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 	chLcqk := make(chan struct{}, 1)
 	go func() {
 		// compute qk in canonical basis, completed with the public inputs
-		lcqk = pk.trace.Qk.Clone(int(pk.Domain[1].Cardinality)).ToLagrange(&pk.Domain[0]).ToRegular()
+		lcqk = toBasis(pk.trace.Qk.Clone(int(pk.Domain[1].Cardinality)), iop.Lagrange, &pk.Domain[0], opt.FFTProvider).ToRegular()
 		qkCompletedCanonical := lcqk.Coefficients()
 		copy(qkCompletedCanonical, fw[:len(spr.Public)])
 		for i := range commitmentInfo {
 			qkCompletedCanonical[spr.GetNbPublicVariables()+commitmentInfo[i].CommitmentIndex] = commitmentVal[i]
 		}
-		lcqk.ToCanonical(&pk.Domain[0]).ToRegular().
-			ToLagrangeCoset(&pk.Domain[1]).ToRegular()
+		toBasis(lcqk, iop.Canonical, &pk.Domain[0], opt.FFTProvider).ToRegular()
+		toBasis(lcqk, iop.LagrangeCoset, &pk.Domain[1], opt.FFTProvider).ToRegular()
 		close(chLcqk)
 	}()
 
 	// The first challenge is derived using the public data: the commitments to the permutation,
 	// the coefficients of the circuit, and the public inputs.
 	// derive gamma from the Comm(blinded cl), Comm(blinded cr), Comm(blinded co)
-	if err := bindPublicData(&fs, "gamma", pk.Vk, fw[:len(spr.Public)]); err != nil {
+	if err := bindPublicData(&fs, "gamma", pk.Vk, fw[:len(spr.Public)], opt.ProofBinding); err != nil {
 		return nil, err
 	}
 
@@ -239,17 +303,17 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 
 	go func() {
 		lcbwliop = bwliop.Clone(int(pk.Domain[1].Cardinality))
-		lcbwliop.ToLagrangeCoset(&pk.Domain[1]).ToRegular()
+		toBasis(lcbwliop, iop.LagrangeCoset, &pk.Domain[1], opt.FFTProvider).ToRegular()
 		wgLRO.Done()
 	}()
 	go func() {
 		lcbwriop = bwriop.Clone(int(pk.Domain[1].Cardinality))
-		lcbwriop.ToLagrangeCoset(&pk.Domain[1]).ToRegular()
+		toBasis(lcbwriop, iop.LagrangeCoset, &pk.Domain[1], opt.FFTProvider).ToRegular()
 		wgLRO.Done()
 	}()
 	go func() {
 		lcbwoiop = bwoiop.Clone(int(pk.Domain[1].Cardinality))
-		lcbwoiop.ToLagrangeCoset(&pk.Domain[1]).ToRegular()
+		toBasis(lcbwoiop, iop.LagrangeCoset, &pk.Domain[1], opt.FFTProvider).ToRegular()
 		wgLRO.Done()
 	}()
 
@@ -301,7 +365,7 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 
 		// Store z(g*x)
 		// perf note: converting ToRegular here perfoms better on Apple M1, but not on a hpc machine.
-		bwsziop = bwziop.Clone().ToLagrangeCoset(&pk.Domain[1]) //.ToRegular()
+		bwsziop = toBasis(bwziop.Clone(), iop.LagrangeCoset, &pk.Domain[1], opt.FFTProvider) //.ToRegular()
 
 		chZ <- nil
 		close(chZ)
@@ -416,7 +480,7 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 		lcCommitments[i] = nil
 	}
 
-	h, err := divideByXMinusOne(systemEvaluation, [2]*fft.Domain{&pk.Domain[0], &pk.Domain[1]}) // TODO Rename to DivideByXNMinusOne or DivideByVanishingPoly etc
+	h, err := divideByXMinusOne(systemEvaluation, [2]*fft.Domain{&pk.Domain[0], &pk.Domain[1]}, opt.FFTProvider) // TODO Rename to DivideByXNMinusOne or DivideByVanishingPoly etc
 	if err != nil {
 		return nil, err
 	}
@@ -581,6 +645,36 @@ func Prove(spr *cs.SparseR1CS, pk *ProvingKey, fullWitness witness.Witness, opts
 
 }
 
+// MockProve returns a proof with the shape of the proofs of spr, without
+// running the prover: its points are the generator of G1 and its claimed
+// values are zero. It is marked as a mock proof, so that it is rejected by
+// Verify, and is only meant to exercise the handling of proofs (serialization,
+// transport) quickly.
+func MockProve(spr *cs.SparseR1CS, pk *ProvingKey, publicWitness fr.Vector) (*Proof, error) {
+	if len(publicWitness) != spr.GetNbPublicVariables() {
+		return nil, fmt.Errorf("invalid witness size, got %d, expected %d", len(publicWitness), spr.GetNbPublicVariables())
+	}
+
+	_, _, g1, _ := curve.Generators()
+	nbCommitments := len(pk.Vk.Qcp)
+	proof := &Proof{
+		LRO:              [3]kzg.Digest{g1, g1, g1},
+		Z:                g1,
+		H:                [3]kzg.Digest{g1, g1, g1},
+		Bsb22Commitments: make([]kzg.Digest, nbCommitments),
+		DomainSize:       pk.Domain[0].Cardinality,
+		Mock:             true,
+	}
+	for i := range proof.Bsb22Commitments {
+		proof.Bsb22Commitments[i] = g1
+	}
+	proof.BatchedProof.H = g1
+	proof.BatchedProof.ClaimedValues = make([]fr.Element, 7+nbCommitments)
+	proof.ZShiftedOpening.H = g1
+
+	return proof, nil
+}
+
 func coefficients(p []*iop.Polynomial) [][]fr.Element {
 	res := make([][]fr.Element, len(p))
 	for i, pI := range p {
@@ -816,7 +910,7 @@ func evaluate(result *iop.Polynomial, pk *ProvingKey, f expression, x ...*iop.Po
 // divideByXMinusOne
 // The input must be in LagrangeCoset.
 // The result is in Canonical Regular. (in place using a)
-func divideByXMinusOne(a *iop.Polynomial, domains [2]*fft.Domain) (*iop.Polynomial, error) {
+func divideByXMinusOne(a *iop.Polynomial, domains [2]*fft.Domain, fftProvider backend.FFTProvider) (*iop.Polynomial, error) {
 
 	// check that the basis is LagrangeCoset
 	if a.Basis != iop.LagrangeCoset {
@@ -838,12 +932,54 @@ func divideByXMinusOne(a *iop.Polynomial, domains [2]*fft.Domain) (*iop.Polynomi
 	})
 
 	// TODO @gbotrel this is the only place we do a FFT inverse (on coset) with domain[1]
-	a.ToCanonical(domains[1]).ToRegular()
+	toBasis(a, iop.Canonical, domains[1], fftProvider).ToRegular()
 
 	return a, nil
 
 }
 
+// toBasis converts p to the given basis over the domain d.
+// If fftProvider is nil, this is the same as calling p.ToCanonical(d),
+// p.ToLagrange(d) or p.ToLagrangeCoset(d). Otherwise the transforms are
+// delegated to fftProvider and the result has a regular layout.
+// The conversion is done in place.
+func toBasis(p *iop.Polynomial, basis iop.Basis, d *fft.Domain, fftProvider backend.FFTProvider) *iop.Polynomial {
+	if fftProvider == nil {
+		switch basis {
+		case iop.Canonical:
+			return p.ToCanonical(d)
+		case iop.Lagrange:
+			return p.ToLagrange(d)
+		default:
+			return p.ToLagrangeCoset(d)
+		}
+	}
+	if p.Basis == basis {
+		return p
+	}
+	p.ToRegular()
+	if p.Basis != iop.Canonical {
+		fftProvider.FFTInverse(fr.Vector(p.Coefficients()), p.Basis == iop.LagrangeCoset)
+		p.Basis = iop.Canonical
+	}
+	// no transform occurs here, the coefficients are only padded with zeros up to d.Cardinality
+	p.ToCanonical(d)
+	if basis != iop.Canonical {
+		fftProvider.FFT(fr.Vector(p.Coefficients()), basis == iop.LagrangeCoset)
+		p.Basis = basis
+	}
+	return p
+}
+
+// blind adds nbBlindingFactors random coefficients to p, which must be in
+// canonical basis. p is left unchanged if nbBlindingFactors is 0.
+func blind(p *iop.Polynomial, nbBlindingFactors int) *iop.Polynomial {
+	if nbBlindingFactors == 0 {
+		return p
+	}
+	return p.Blind(nbBlindingFactors - 1)
+}
+
 // evaluateXnMinusOneDomainBigCoset evaluates Xᵐ-1 on DomainBig coset
 func evaluateXnMinusOneDomainBigCoset(domains [2]*fft.Domain) []fr.Element {
 
@@ -21,10 +21,15 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/iop"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
 	"io"
+
+	"github.com/consensys/gnark/backend/plonk/internal"
 )
 
 // WriteRawTo writes binary encoding of Proof to w without point compression
@@ -55,6 +60,14 @@ func (proof *Proof) writeTo(w io.Writer, options ...func(*curve.Encoder)) (int64
 		proof.Bsb22Commitments,
 	}
 
+	// the domain size is only known for proofs in the versioned format, it
+	// is appended after a tag so that proofs in the legacy format still parse.
+	if proof.Mock {
+		toEncode = append(toEncode, mockProofFormatTag, proof.DomainSize)
+	} else if proof.DomainSize != 0 {
+		toEncode = append(toEncode, proofFormatTag, proof.DomainSize)
+	}
+
 	for _, v := range toEncode {
 		if err := enc.Encode(v); err != nil {
 			return enc.BytesWritten(), err
@@ -64,7 +77,50 @@ func (proof *Proof) writeTo(w io.Writer, options ...func(*curve.Encoder)) (int64
 	return enc.BytesWritten(), nil
 }
 
-// ReadFrom reads binary representation of Proof from r
+// proofFormatTag follows the fields of a proof in the legacy format, when they
+// are followed by the fields of the format version 1 ("PLK" || 1).
+const proofFormatTag uint32 = 0x504c4b01
+
+// mockProofFormatTag replaces proofFormatTag in the proofs produced by
+// MockProve ("PLK" || 0xff).
+const mockProofFormatTag uint32 = 0x504c4bff
+
+// ProofSize returns the size in bytes of the binary encoding of the proofs
+// verified with vk, as written by Proof.WriteTo. It only depends on the number
+// of commitments of the circuit.
+func (vk *VerifyingKey) ProofSize() int {
+	nbCommitments := len(vk.Qcp)
+	proof := Proof{
+		Bsb22Commitments: make([]curve.G1Affine, nbCommitments),
+		DomainSize:       vk.Size,
+	}
+	proof.BatchedProof.ClaimedValues = make([]fr.Element, 7+nbCommitments)
+	n, _ := proof.WriteTo(io.Discard)
+	return int(n)
+}
+
+// ProofStructure returns the number of G1 points, G2 points and scalars of a
+// proof of a circuit without BSB22 commitments. Each commitment adds a G1
+// point and a scalar.
+func ProofStructure() (nbG1, nbG2, nbScalars int) {
+	var proof Proof
+	// LRO, Z, H, and the quotients of the two KZG opening proofs
+	nbG1 = len(proof.LRO) + 1 + len(proof.H) + 2
+	// the 7 values opened at ζ and Z(ζω)
+	nbScalars = 7 + 1
+	return nbG1, 0, nbScalars
+}
+
+// ReadFrom reads binary representation of Proof from r. Proofs in the legacy
+// format, without domain size, are told apart by the absence of format tag: the
+// bytes following them are left unread if r is a *bufio.Reader, a
+// *bytes.Buffer or an io.Seeker, and must be the end of r otherwise.
+//
+// The points of a proof are all in G1, and the decoder checks that they are in
+// the r-torsion. This check is already the fast one: it uses the endomorphism
+// of the curve when G1 has a cofactor, and reduces to the curve equation when
+// it has none, as on BN254. There is no G2 point in a proof, so that there is
+// no costly subgroup check to skip when reading it.
 func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
 	dec := curve.NewDecoder(r)
 	toDecode := []interface{}{
@@ -92,20 +148,46 @@ func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
 		proof.Bsb22Commitments = []kzg.Digest{}
 	}
 
-	return dec.BytesRead(), nil
+	// versioned format, detected from the tag alone so that the bytes
+	// following a legacy proof are left unread
+	proof.DomainSize = 0
+	proof.Mock = false
+	tag, ok, err := internal.ReadTag(r, proofFormatTag, mockProofFormatTag)
+	if err != nil {
+		return dec.BytesRead(), err
+	}
+	if !ok {
+		// legacy format
+		return dec.BytesRead(), nil
+	}
+	proof.Mock = tag == mockProofFormatTag
+	const tagSize = 4
+	if err := dec.Decode(&proof.DomainSize); err != nil {
+		return dec.BytesRead() + tagSize, err
+	}
+
+	return dec.BytesRead() + tagSize, nil
 }
 
 // WriteTo writes binary encoding of ProvingKey to w
 func (pk *ProvingKey) WriteTo(w io.Writer) (n int64, err error) {
-	return pk.writeTo(w, true)
+	return pk.writeTo(w, true, true)
 }
 
 // WriteRawTo writes binary encoding of ProvingKey to w without point compression
 func (pk *ProvingKey) WriteRawTo(w io.Writer) (n int64, err error) {
-	return pk.writeTo(w, false)
+	return pk.writeTo(w, false, true)
 }
 
-func (pk *ProvingKey) writeTo(w io.Writer, withCompression bool) (n int64, err error) {
+// WriteWithoutSRS writes binary encoding of ProvingKey to w, replacing the points
+// of the KZG SRS by a marker: their number and the SHA256 digest of their raw
+// encoding. The proving keys of circuits set up with the same SRS can then be
+// stored without duplicating it, and decoded with ReadWithSRS.
+func (pk *ProvingKey) WriteWithoutSRS(w io.Writer) (n int64, err error) {
+	return pk.writeTo(w, true, false)
+}
+
+func (pk *ProvingKey) writeTo(w io.Writer, withCompression, withSRS bool) (n int64, err error) {
 	// encode the verifying key
 	if withCompression {
 		n, err = pk.Vk.WriteTo(w)
@@ -130,7 +212,9 @@ func (pk *ProvingKey) writeTo(w io.Writer, withCompression bool) (n int64, err e
 	n += n2
 
 	// KZG key
-	if withCompression {
+	if !withSRS {
+		n2, err = writeSRSMarker(w, pk.Kzg)
+	} else if withCompression {
 		n2, err = pk.Kzg.WriteTo(w)
 	} else {
 		n2, err = pk.Kzg.WriteRawTo(w)
@@ -173,15 +257,22 @@ func (pk *ProvingKey) writeTo(w io.Writer, withCompression bool) (n int64, err e
 
 // ReadFrom reads from binary representation in r into ProvingKey
 func (pk *ProvingKey) ReadFrom(r io.Reader) (int64, error) {
-	return pk.readFrom(r, true)
+	return pk.readFrom(r, true, nil)
 }
 
 // UnsafeReadFrom reads from binary representation in r into ProvingKey without subgroup checks
 func (pk *ProvingKey) UnsafeReadFrom(r io.Reader) (int64, error) {
-	return pk.readFrom(r, false)
+	return pk.readFrom(r, false, nil)
+}
+
+// ReadWithSRS reads from the binary representation written by WriteWithoutSRS in r
+// into ProvingKey, and attaches the points of srs to it. It returns an error if
+// srs is not the SRS the proving key was set up with, or a truncation of it.
+func (pk *ProvingKey) ReadWithSRS(r io.Reader, srs kzg.SRS) (int64, error) {
+	return pk.readFrom(r, true, &srs)
 }
 
-func (pk *ProvingKey) readFrom(r io.Reader, withSubgroupChecks bool) (int64, error) {
+func (pk *ProvingKey) readFrom(r io.Reader, withSubgroupChecks bool, srs *kzg.SRS) (int64, error) {
 	pk.Vk = &VerifyingKey{}
 	n, err := pk.Vk.ReadFrom(r)
 	if err != nil {
@@ -200,7 +291,9 @@ func (pk *ProvingKey) readFrom(r io.Reader, withSubgroupChecks bool) (int64, err
 		return n, err
 	}
 
-	if withSubgroupChecks {
+	if srs != nil {
+		pk.Kzg, n2, err = readSRSMarker(r, srs.Pk)
+	} else if withSubgroupChecks {
 		n2, err = pk.Kzg.ReadFrom(r)
 	} else {
 		n2, err = pk.Kzg.UnsafeReadFrom(r)
@@ -302,6 +395,55 @@ func (pk *ProvingKey) readFrom(r io.Reader, withSubgroupChecks bool) (int64, err
 
 }
 
+// srsDigest returns the SHA256 digest of the raw encoding of the points of the
+// KZG proving key, which identifies the SRS a proving key was set up with.
+func srsDigest(kzgPk kzg.ProvingKey) ([sha256.Size]byte, error) {
+	var digest [sha256.Size]byte
+	h := sha256.New()
+	if _, err := kzgPk.WriteRawTo(h); err != nil {
+		return digest, err
+	}
+	copy(digest[:], h.Sum(nil))
+	return digest, nil
+}
+
+// writeSRSMarker writes the number of points of the KZG proving key and their
+// digest to w, in place of the points themselves.
+func writeSRSMarker(w io.Writer, kzgPk kzg.ProvingKey) (int64, error) {
+	digest, err := srsDigest(kzgPk)
+	if err != nil {
+		return 0, err
+	}
+	var buf [8 + sha256.Size]byte
+	binary.BigEndian.PutUint64(buf[:8], uint64(len(kzgPk.G1)))
+	copy(buf[8:], digest[:])
+	n, err := w.Write(buf[:])
+	return int64(n), err
+}
+
+// readSRSMarker reads the marker written by writeSRSMarker from r, and returns
+// the KZG proving key made of the first points of srsPk it identifies.
+func readSRSMarker(r io.Reader, srsPk kzg.ProvingKey) (kzg.ProvingKey, int64, error) {
+	var buf [8 + sha256.Size]byte
+	n, err := io.ReadFull(r, buf[:])
+	if err != nil {
+		return kzg.ProvingKey{}, int64(n), err
+	}
+	size := binary.BigEndian.Uint64(buf[:8])
+	if uint64(len(srsPk.G1)) < size {
+		return kzg.ProvingKey{}, int64(n), errors.New("kzg srs is too small")
+	}
+	kzgPk := kzg.ProvingKey{G1: srsPk.G1[:size]}
+	digest, err := srsDigest(kzgPk)
+	if err != nil {
+		return kzg.ProvingKey{}, int64(n), err
+	}
+	if !bytes.Equal(digest[:], buf[8:]) {
+		return kzg.ProvingKey{}, int64(n), errors.New("kzg srs does not match the proving key")
+	}
+	return kzgPk, int64(n), nil
+}
+
 // WriteTo writes binary encoding of VerifyingKey to w
 func (vk *VerifyingKey) WriteTo(w io.Writer) (n int64, err error) {
 	return vk.writeTo(w)
@@ -312,6 +454,19 @@ func (vk *VerifyingKey) WriteRawTo(w io.Writer) (int64, error) {
 	return vk.writeTo(w, curve.RawEncoding())
 }
 
+// Fingerprint returns the SHA-256 digest of the binary encoding of vk (see
+// WriteTo). It identifies the verifying key, so that a verifier can store the
+// fingerprint only and check a verifying key fetched from an untrusted source
+// against it.
+func (vk *VerifyingKey) Fingerprint() []byte {
+	h := sha256.New()
+	if _, err := vk.WriteTo(h); err != nil {
+		// the hash doesn't return errors, the encoding of vk is always valid
+		panic(err)
+	}
+	return h.Sum(nil)
+}
+
 func (vk *VerifyingKey) writeTo(w io.Writer, options ...func(*curve.Encoder)) (n int64, err error) {
 	enc := curve.NewEncoder(w)
 
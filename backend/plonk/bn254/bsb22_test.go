@@ -0,0 +1,81 @@
+package plonk
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/iop"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	"github.com/consensys/gnark/constraint"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/stretchr/testify/require"
+)
+
+type commitmentCircuit struct {
+	X [3]frontend.Variable
+}
+
+func (c *commitmentCircuit) Define(api frontend.API) error {
+	commitment, err := api.(frontend.Committer).Commit(c.X[:]...)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(commitment, 0)
+	return nil
+}
+
+func TestBsb22CommitmentBlinding(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+	assert.NoError(err)
+	spr := ccs.(*cs.SparseR1CS)
+	srs, err := kzg.NewSRS(ecc.NextPowerOfTwo(uint64(spr.GetNbConstraints()+spr.GetNbPublicVariables()))+3, big.NewInt(42))
+	assert.NoError(err)
+	pk, _, err := Setup(spr, *srs)
+	assert.NoError(err)
+
+	// the committed values, at their position in the trace
+	ins := []*big.Int{big.NewInt(3), big.NewInt(5), big.NewInt(7)}
+	info := spr.CommitmentInfo.(constraint.PlonkCommitments)[0]
+	offset := spr.GetNbPublicVariables()
+	expected := make([]fr.Element, pk.Domain[0].Cardinality)
+	for i := range ins {
+		expected[offset+info.Committed[i]].SetBigInt(ins[i])
+	}
+	// the positions where qcp is zero, used for blinding
+	blindingPositions := []int{offset + info.CommitmentIndex, offset + spr.GetNbConstraints() - 1}
+
+	commit := func(blind bool) (kzg.Digest, []fr.Element) {
+		proof := Proof{Bsb22Commitments: make([]kzg.Digest, 1)}
+		cCommitments := make([]*iop.Polynomial, 1)
+		var res fr.Element
+		hint := bsb22ComputeCommitmentHint(spr, pk, &proof, cCommitments, &res, 0, nil, blind)
+		assert.NoError(hint(fr.Modulus(), ins, []*big.Int{new(big.Int)}))
+		values := cCommitments[0].Clone().ToLagrange(&pk.Domain[0]).ToRegular().Coefficients()
+		return proof.Bsb22Commitments[0], values
+	}
+
+	// without blinding, the committed polynomial interpolates the committed
+	// values only
+	unblinded, values := commit(false)
+	assert.Equal(expected, values)
+	other, _ := commit(false)
+	assert.Equal(unblinded, other)
+
+	// with blinding, it additionally takes fresh random values at the
+	// blinding positions
+	blinded, values := commit(true)
+	for _, i := range blindingPositions {
+		assert.False(values[i].IsZero(), "position %d should be blinded", i)
+		expected[i] = values[i]
+	}
+	assert.Equal(expected, values)
+	assert.NotEqual(unblinded, blinded)
+	other, _ = commit(true)
+	assert.NotEqual(blinded, other)
+}
@@ -22,10 +22,15 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+
+	"bufio"
+	"bytes"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr/iop"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
 	"github.com/consensys/gnark/io"
 	"math/big"
 	"math/rand"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +44,78 @@ func TestProofSerialization(t *testing.T) {
 	assert.NoError(t, io.RoundTripCheck(&proof, func() interface{} { return new(Proof) }))
 }
 
+func TestProofSerializationDomainSize(t *testing.T) {
+	// proofs in the legacy format have no domain size, the versioned ones do
+	var proof Proof
+	proof.randomize()
+	proof.DomainSize = 1 << 10
+
+	assert.NoError(t, io.RoundTripCheck(&proof, func() interface{} { return new(Proof) }))
+
+	var legacy, versioned bytes.Buffer
+	_, err := proof.WriteTo(&versioned)
+	assert.NoError(t, err)
+	proof.DomainSize = 0
+	_, err = proof.WriteTo(&legacy)
+	assert.NoError(t, err)
+	assert.Equal(t, legacy.Bytes(), versioned.Bytes()[:legacy.Len()], "the versioned format should extend the legacy one")
+
+	var decoded Proof
+	_, err = decoded.ReadFrom(&versioned)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1<<10), decoded.DomainSize)
+}
+
+func TestProofSerializationLegacyWithTrailingData(t *testing.T) {
+	// a legacy proof is detected from the absence of format tag, the bytes
+	// following it are left to the next decoder
+	var legacy, next Proof
+	legacy.randomize()
+	next.randomize()
+	next.DomainSize = 1 << 10
+
+	var buf bytes.Buffer
+	_, err := legacy.WriteTo(&buf)
+	assert.NoError(t, err)
+	_, err = next.WriteTo(&buf)
+	assert.NoError(t, err)
+
+	readers := map[string]func() interface{ Read([]byte) (int, error) }{
+		"bytes.Buffer": func() interface{ Read([]byte) (int, error) } { return bytes.NewBuffer(buf.Bytes()) },
+		"bytes.Reader": func() interface{ Read([]byte) (int, error) } { return bytes.NewReader(buf.Bytes()) },
+		"bufio.Reader": func() interface{ Read([]byte) (int, error) } { return bufio.NewReader(bytes.NewReader(buf.Bytes())) },
+	}
+	for name, newReader := range readers {
+		r := newReader()
+		var decodedLegacy, decodedNext Proof
+		_, err = decodedLegacy.ReadFrom(r)
+		assert.NoError(t, err, name)
+		assert.Equal(t, uint64(0), decodedLegacy.DomainSize, name)
+		assert.True(t, reflect.DeepEqual(&legacy, &decodedLegacy), name)
+		_, err = decodedNext.ReadFrom(r)
+		assert.NoError(t, err, name)
+		assert.Equal(t, uint64(1<<10), decodedNext.DomainSize, name)
+		assert.True(t, reflect.DeepEqual(&next, &decodedNext), name)
+	}
+}
+
+func TestProofSerializationMock(t *testing.T) {
+	var proof Proof
+	proof.randomize()
+	proof.DomainSize = 1 << 10
+	proof.Mock = true
+
+	assert.NoError(t, io.RoundTripCheck(&proof, func() interface{} { return new(Proof) }))
+
+	var buf bytes.Buffer
+	_, err := proof.WriteTo(&buf)
+	assert.NoError(t, err)
+	var decoded Proof
+	_, err = decoded.ReadFrom(&buf)
+	assert.NoError(t, err)
+	assert.True(t, decoded.Mock)
+}
+
 func TestProvingKeySerialization(t *testing.T) {
 	// random pk
 	var pk ProvingKey
@@ -47,6 +124,39 @@ func TestProvingKeySerialization(t *testing.T) {
 	assert.NoError(t, io.RoundTripCheck(&pk, func() interface{} { return new(ProvingKey) }))
 }
 
+func TestProvingKeySerializationWithoutSRS(t *testing.T) {
+	var pk ProvingKey
+	pk.randomize()
+	// a copy, so that the changes to srs don't reach pk
+	g1 := make([]curve.G1Affine, len(pk.Kzg.G1), len(pk.Kzg.G1)+1)
+	copy(g1, pk.Kzg.G1)
+	srs := kzg.SRS{Pk: kzg.ProvingKey{G1: append(g1, randomG1Point())}}
+
+	var withSRS, withoutSRS bytes.Buffer
+	_, err := pk.WriteTo(&withSRS)
+	assert.NoError(t, err)
+	written, err := pk.WriteWithoutSRS(&withoutSRS)
+	assert.NoError(t, err)
+	assert.Less(t, withoutSRS.Len(), withSRS.Len())
+
+	// the larger SRS is truncated to the size of the original one
+	var decoded ProvingKey
+	read, err := decoded.ReadWithSRS(bytes.NewReader(withoutSRS.Bytes()), srs)
+	assert.NoError(t, err)
+	assert.Equal(t, written, read)
+	assert.True(t, reflect.DeepEqual(&pk, &decoded))
+
+	// another SRS is rejected
+	srs.Pk.G1[0] = randomG1Point()
+	_, err = new(ProvingKey).ReadWithSRS(bytes.NewReader(withoutSRS.Bytes()), srs)
+	assert.Error(t, err)
+
+	// so is a smaller one
+	srs.Pk.G1 = pk.Kzg.G1[:len(pk.Kzg.G1)-1]
+	_, err = new(ProvingKey).ReadWithSRS(bytes.NewReader(withoutSRS.Bytes()), srs)
+	assert.Error(t, err)
+}
+
 func TestVerifyingKeySerialization(t *testing.T) {
 	// create a random vk
 	var vk VerifyingKey
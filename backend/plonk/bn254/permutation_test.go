@@ -0,0 +1,76 @@
+package plonk
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/iop"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/stretchr/testify/require"
+)
+
+type permutationCircuit struct {
+	X, Y frontend.Variable `gnark:",public"`
+	Z    frontend.Variable
+}
+
+func (c *permutationCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.Z), api.Add(c.Y, c.Z))
+	return nil
+}
+
+func TestPermutationComponents(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &permutationCircuit{})
+	assert.NoError(err)
+	spr := ccs.(*cs.SparseR1CS)
+	srs, err := kzg.NewSRS(ecc.NextPowerOfTwo(uint64(spr.GetNbConstraints()+spr.GetNbPublicVariables()))+3, big.NewInt(42))
+	assert.NoError(err)
+	pk, vk, err := Setup(spr, *srs)
+	assert.NoError(err)
+
+	// the i-th value of Sₖ is the label uʲωⁱ' of the position S[kn+i] = jn+i'
+	// the permutation sends the i-th wire of the k-th column to.
+	n := pk.Domain[0].Cardinality
+	var shifts [3]fr.Element
+	shifts[0].SetOne()
+	shifts[1].Set(&pk.Domain[0].FrMultiplicativeGen)
+	shifts[2].Square(&shifts[1])
+	commitments := vk.PermutationCommitments()
+	assert.Len(commitments, 3)
+	for k := range commitments {
+		values := make([]fr.Element, n)
+		for i := range values {
+			s := uint64(pk.trace.S[uint64(k)*n+uint64(i)])
+			values[i].Exp(pk.Domain[0].Generator, new(big.Int).SetUint64(s%n)).
+				Mul(&values[i], &shifts[s/n])
+		}
+		p := iop.NewPolynomial(&values, iop.Form{Basis: iop.Lagrange, Layout: iop.Regular})
+		p.ToCanonical(&pk.Domain[0]).ToRegular()
+		digest, err := kzg.Commit(p.Coefficients(), pk.Kzg)
+		assert.NoError(err)
+		expected := digest.Bytes()
+		assert.Equal(expected[:], commitments[k], "S%d", k+1)
+	}
+
+	// Z(ζω) is the value at which the commitment to Z opens at ζω
+	fullWitness, err := frontend.NewWitness(&permutationCircuit{X: 3, Y: 4, Z: 2}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+	proof, err := Prove(spr, pk, fullWitness)
+	assert.NoError(err)
+	claims, err := reduceToOpeningClaims(proof, vk, publicWitness.Vector().(fr.Vector), nil, nil)
+	assert.NoError(err)
+	opening := kzg.OpeningProof{H: proof.ZShiftedOpening.H}
+	assert.NoError(opening.ClaimedValue.SetBytesCanonical(proof.PermutationEval()))
+	assert.NoError(kzg.Verify(&proof.Z, &opening, claims.points[1], vk.Kzg))
+	opening.ClaimedValue.Add(&opening.ClaimedValue, &shifts[0])
+	assert.Error(kzg.Verify(&proof.Z, &opening, claims.points[1], vk.Kzg))
+}
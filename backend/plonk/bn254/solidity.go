@@ -1,5 +1,12 @@
 package plonk
 
+import (
+	"encoding/binary"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"golang.org/x/crypto/sha3"
+)
+
 const tmplSolidityVerifier = `// SPDX-License-Identifier: Apache-2.0
 
 // Copyright 2023 Consensys Software Inc.
@@ -1205,6 +1212,17 @@ contract PlonkVerifier {
       }
     }
   }
+  {{- if .PublicInputNames }}
+
+  function verify(bytes calldata proof{{ range .PublicInputNames }}, uint256 {{ . }}{{ end }})
+  public view returns(bool success) {
+    uint256[] memory public_inputs = new uint256[]({{ len .PublicInputNames }});
+    {{ range $index, $name := .PublicInputNames -}}
+    public_inputs[{{ $index }}] = {{ $name }};
+    {{ end -}}
+    return this.Verify(proof, public_inputs);
+  }
+  {{- end }}
 }
 `
 
@@ -1290,3 +1308,86 @@ func (proof *Proof) MarshalSolidity() []byte {
 
 	return res
 }
+
+// verifySignature is the signature of the entry point of the verifier exported
+// by ExportSolidity.
+const verifySignature = "Verify(bytes,uint256[])"
+
+// EncodeCalldata returns the calldata of a call to the Verify function of the
+// contract exported by ExportSolidity, checking proof against publicWitness.
+// It is the 4 bytes function selector followed by the ABI encoding of the
+// proof, as returned by MarshalSolidity, and of the public inputs.
+func (proof *Proof) EncodeCalldata(publicWitness fr.Vector) []byte {
+	selector := sha3.NewLegacyKeccak256()
+	selector.Write([]byte(verifySignature))
+
+	proofBytes := proof.MarshalSolidity()
+	paddedLen := (len(proofBytes) + 31) / 32 * 32
+
+	// head: offsets of the two dynamic arguments, then the tails: the length
+	// prefixed proof, padded to a multiple of 32 bytes, and the length prefixed
+	// public inputs.
+	res := make([]byte, 0, 4+2*32+32+paddedLen+32+len(publicWitness)*fr.Bytes)
+	res = selector.Sum(res)[:4]
+	res = appendUint256(res, 2*32)
+	res = appendUint256(res, uint64(2*32+32+paddedLen))
+	res = appendUint256(res, uint64(len(proofBytes)))
+	res = append(res, proofBytes...)
+	res = append(res, make([]byte, paddedLen-len(proofBytes))...)
+	res = appendUint256(res, uint64(len(publicWitness)))
+	for i := range publicWitness {
+		b := publicWitness[i].Bytes()
+		res = append(res, b[:]...)
+	}
+
+	return res
+}
+
+// appendUint256 appends the 32 bytes big-endian encoding of v to b.
+func appendUint256(b []byte, v uint64) []byte {
+	var buf [32]byte
+	binary.BigEndian.PutUint64(buf[24:], v)
+	return append(b, buf[:]...)
+}
+
+// Gas costs used by EstimateSolidityGas. The precompile costs are the ones of
+// EIP-1108 and EIP-2565 and the calldata cost the one of EIP-2028. The cost of
+// the public inputs and the overhead are checked against the gas used by the
+// exported contract in TestEstimateSolidityGas, run with -tags solccheck.
+const (
+	gasPairing      = 45000 + 2*34000 // one pairing check with two pairs
+	gasEcMul        = 6000
+	gasEcAdd        = 150
+	gasModExp       = 1360 // 256 bits exponent, 256 bits modulus
+	gasCalldataByte = 16
+	gasPublicInput  = 300   // Lagrange evaluation and hashing of a public input
+	gasOverhead     = 30000 // challenges derivation, field arithmetic and memory
+)
+
+// EstimateSolidityGas returns an estimate of the gas used by a call to the
+// Verify function of the contract exported by ExportSolidity. It counts the
+// precompile calls made by the verifier and the calldata, which dominate the
+// cost, and adds a fixed overhead for the rest of the execution. The
+// transaction base cost is not included.
+func (vk *VerifyingKey) EstimateSolidityGas() uint64 {
+	nbCommitments := uint64(len(vk.CommitmentConstraintIndexes))
+	nbPublic := vk.NbPublicVariables
+
+	// scalar multiplications and additions folding the commitments and the
+	// quotient, and exponentiations of ζ and of the inverted denominators.
+	nbEcMul := 19 + 2*nbCommitments
+	nbEcAdd := 19 + 2*nbCommitments
+	nbModExp := 4 + 2*nbCommitments
+
+	// selector, proof as returned by MarshalSolidity and public inputs, see
+	// EncodeCalldata.
+	calldataSize := 4 + 3*32 + (832 + 96*nbCommitments) + 32 + 32*nbPublic
+
+	return gasPairing +
+		nbEcMul*gasEcMul +
+		nbEcAdd*gasEcAdd +
+		nbModExp*gasModExp +
+		calldataSize*gasCalldataByte +
+		nbPublic*gasPublicInput +
+		gasOverhead
+}
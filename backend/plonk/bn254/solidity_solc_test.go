@@ -0,0 +1,144 @@
+//go:build solccheck
+
+package plonk
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExportSolidityNamedSolc checks with solc, which must be in the PATH, that
+// the names accepted by ExportSolidityNamed compile, and that the elementary
+// type names it rejects don't.
+func TestExportSolidityNamedSolc(t *testing.T) {
+	assert := require.New(t)
+
+	var vk VerifyingKey
+	vk.randomize()
+	vk.NbPublicVariables = 2
+
+	compile := func(names []string) error {
+		f, err := os.Create(filepath.Join(t.TempDir(), "verifier.sol"))
+		assert.NoError(err)
+		assert.NoError(vk.exportSolidity(f, names))
+		assert.NoError(f.Close())
+		out, err := exec.Command("solc", "--bin", f.Name()).CombinedOutput()
+		if err != nil {
+			t.Log(string(out))
+		}
+		return err
+	}
+
+	for _, name := range append([]string{"nullifier"}, solidityTypeLikeNames...) {
+		assert.NoError(vk.ExportSolidityNamed(io.Discard, []string{"root", name}))
+		assert.NoError(compile([]string{"root", name}), "%q is accepted and must compile", name)
+	}
+	for _, name := range solidityTypeNames {
+		assert.Error(compile([]string{"root", name}), "%q is rejected and must not compile", name)
+	}
+}
+
+type gasCircuit struct {
+	X             [4]frontend.Variable `gnark:",public"`
+	Y             frontend.Variable
+	nbCommitments int
+}
+
+func (c *gasCircuit) Define(api frontend.API) error {
+	for i := 0; i < c.nbCommitments; i++ {
+		commitment, err := api.(frontend.Committer).Commit(c.X[i], c.Y)
+		if err != nil {
+			return err
+		}
+		api.AssertIsDifferent(commitment, 0)
+	}
+	api.AssertIsEqual(api.Add(c.X[0], c.X[1], c.X[2], c.X[3]), c.Y)
+	return nil
+}
+
+var evmGasUsed = regexp.MustCompile(`EVM gas used:\s*(\d+)`)
+
+// TestEstimateSolidityGas checks the gas constants of EstimateSolidityGas
+// against the gas used by the exported contract. It compiles the contract with
+// solc and runs it with the evm tool of go-ethereum, which must both be in the
+// PATH. The gas measured is the one of the execution, plus the calldata cost
+// of EIP-2028.
+func TestEstimateSolidityGas(t *testing.T) {
+	for nbCommitments := 0; nbCommitments <= 2; nbCommitments++ {
+		t.Run(strconv.Itoa(nbCommitments), func(t *testing.T) {
+			assert := require.New(t)
+
+			ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &gasCircuit{nbCommitments: nbCommitments})
+			assert.NoError(err)
+			spr := ccs.(*cs.SparseR1CS)
+			srs, err := kzg.NewSRS(ecc.NextPowerOfTwo(uint64(spr.GetNbConstraints()+spr.GetNbPublicVariables()))+3, fr.Modulus())
+			assert.NoError(err)
+			pk, vk, err := Setup(spr, *srs)
+			assert.NoError(err)
+
+			fullWitness, err := frontend.NewWitness(&gasCircuit{X: [4]frontend.Variable{1, 2, 3, 4}, Y: 10}, ecc.BN254.ScalarField())
+			assert.NoError(err)
+			publicWitness, err := fullWitness.Public()
+			assert.NoError(err)
+			proof, err := Prove(spr, pk, fullWitness)
+			assert.NoError(err)
+			calldata := proof.EncodeCalldata(publicWitness.Vector().(fr.Vector))
+
+			// compile the contract
+			dir := t.TempDir()
+			f, err := os.Create(filepath.Join(dir, "verifier.sol"))
+			assert.NoError(err)
+			assert.NoError(vk.ExportSolidity(f))
+			assert.NoError(f.Close())
+			out, err := exec.Command("solc", "--optimize", "--combined-json", "bin-runtime", f.Name()).Output()
+			assert.NoError(err)
+			var compiled struct {
+				Contracts map[string]struct {
+					BinRuntime string `json:"bin-runtime"`
+				} `json:"contracts"`
+			}
+			assert.NoError(json.Unmarshal(out, &compiled))
+			code := compiled.Contracts[f.Name()+":PlonkVerifier"].BinRuntime
+			assert.NotEmpty(code)
+
+			// run Verify
+			out, err = exec.Command("evm", "--code", code, "--input", hex.EncodeToString(calldata), "--statdump", "run").CombinedOutput()
+			assert.NoError(err, string(out))
+			assert.True(strings.HasPrefix(string(out), "0x"+strings.Repeat("0", 63)+"1"), "the proof must verify: %s", out)
+			m := evmGasUsed.FindSubmatch(out)
+			assert.NotNil(m, string(out))
+			measured, err := strconv.ParseUint(string(m[1]), 10, 64)
+			assert.NoError(err)
+			for _, b := range calldata {
+				if b == 0 {
+					measured += 4
+				} else {
+					measured += 16
+				}
+			}
+
+			// the estimate counts all the calldata bytes as non-zero ones, so
+			// it must not be below the gas used, and within 10% of it.
+			estimate := vk.EstimateSolidityGas()
+			t.Logf("estimated %d gas, measured %d", estimate, measured)
+			assert.GreaterOrEqual(estimate, measured)
+			assert.LessOrEqual(estimate, measured+measured/10)
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package plonk
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportSolidityNamed(t *testing.T) {
+	var vk VerifyingKey
+	vk.randomize()
+	vk.NbPublicVariables = 2
+
+	var unnamed, named bytes.Buffer
+	assert.NoError(t, vk.ExportSolidity(&unnamed))
+	assert.NoError(t, vk.ExportSolidityNamed(&named, []string{"root", "_nullifier"}))
+
+	assert.NotContains(t, unnamed.String(), "function verify(")
+	assert.Contains(t, named.String(), "function verify(bytes calldata proof, uint256 root, uint256 _nullifier)")
+	assert.Contains(t, named.String(), "public_inputs[1] = _nullifier;")
+	assert.True(t, strings.HasPrefix(named.String(), strings.TrimSuffix(unnamed.String(), "}\n")))
+
+	for _, names := range [][]string{
+		{"root"},
+		{"root", "1nullifier"},
+		{"root", "null-ifier"},
+		{"root", "root"},
+		{"root", "contract"},
+		{"root", "proof"},
+		{"root", "uint"},
+		{"root", "uint8"},
+		{"root", "int256"},
+		{"root", "bytes32"},
+		{"root", "fixed"},
+		{"root", "ufixed128x18"},
+		{"root", "ether"},
+	} {
+		assert.Error(t, vk.ExportSolidityNamed(&named, names), "names %v should be rejected", names)
+	}
+
+	// names close to the type names, which are identifiers
+	for _, name := range solidityTypeLikeNames {
+		assert.NoError(t, vk.ExportSolidityNamed(&named, []string{"root", name}), "name %q should be accepted", name)
+	}
+}
+
+// solidityTypeLikeNames are valid identifiers despite their resemblance to the
+// elementary type names.
+var solidityTypeLikeNames = []string{"uint7", "int264", "uint08", "bytes0", "bytes33", "fixedx8", "fixed8", "ufixed8x81", "uints", "bytes_"}
+
+// solidityTypeNames are elementary type names, which can't be identifiers.
+var solidityTypeNames = []string{"int", "uint", "bytes", "fixed", "ufixed", "uint8", "int256", "bytes1", "bytes32", "fixed8x0", "ufixed256x80"}
+
+func TestIsSolidityTypeName(t *testing.T) {
+	for _, name := range solidityTypeNames {
+		assert.True(t, isSolidityTypeName(name), name)
+	}
+	for _, name := range solidityTypeLikeNames {
+		assert.False(t, isSolidityTypeName(name), name)
+	}
+}
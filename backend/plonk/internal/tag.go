@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// ErrTagNotFound is returned by ReadTag when the bytes following a legacy
+// proof were consumed from a reader on which they can't be put back.
+var ErrTagNotFound = errors.New("no format tag after the proof, and the following bytes can't be unread: use a reader implementing io.Seeker")
+
+// ReadTag reads the big-endian uint32 at the start of r if it is one of tags,
+// and returns true. Otherwise it returns false, and the bytes of r are left
+// unread, so that whatever follows a proof in the legacy format can still be
+// decoded.
+//
+// The next bytes are peeked when r is a *bufio.Reader or a *bytes.Buffer, and
+// read then put back when r implements io.Seeker. On other readers, they can
+// only be put back when they are the end of r: ErrTagNotFound is returned if
+// they aren't.
+func ReadTag(r io.Reader, tags ...uint32) (uint32, bool, error) {
+	isTag := func(b []byte) (uint32, bool) {
+		if len(b) < 4 {
+			return 0, false
+		}
+		tag := binary.BigEndian.Uint32(b)
+		for _, t := range tags {
+			if t == tag {
+				return tag, true
+			}
+		}
+		return 0, false
+	}
+
+	var buf [4]byte
+	switch rr := r.(type) {
+	case interface{ Peek(int) ([]byte, error) }:
+		// *bufio.Reader; a short read means that there is no tag
+		b, _ := rr.Peek(len(buf))
+		tag, ok := isTag(b)
+		if !ok {
+			return 0, false, nil
+		}
+		_, err := io.ReadFull(r, buf[:])
+		return tag, err == nil, err
+	case interface{ Bytes() []byte }:
+		// *bytes.Buffer, Bytes returns the unread portion of the buffer
+		tag, ok := isTag(rr.Bytes())
+		if !ok {
+			return 0, false, nil
+		}
+		_, err := io.ReadFull(r, buf[:])
+		return tag, err == nil, err
+	case io.Seeker:
+		n, err := io.ReadFull(r, buf[:])
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, false, err
+		}
+		if tag, ok := isTag(buf[:n]); ok {
+			return tag, true, nil
+		}
+		if _, err := rr.Seek(int64(-n), io.SeekCurrent); err != nil {
+			return 0, false, err
+		}
+		return 0, false, nil
+	default:
+		n, err := io.ReadFull(r, buf[:])
+		if n == 0 && err == io.EOF {
+			return 0, false, nil
+		}
+		if tag, ok := isTag(buf[:n]); ok {
+			return tag, true, nil
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return 0, false, err
+		}
+		return 0, false, ErrTagNotFound
+	}
+}
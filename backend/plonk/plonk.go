@@ -14,13 +14,26 @@
 
 // Package plonk implements PLONK Zero Knowledge Proof system.
 //
+// # WebAssembly
+//
+// The verifier only depends on pure Go code on the architectures without
+// assembly implementation of the field arithmetic in gnark-crypto, such as
+// GOOS=js GOARCH=wasm: [Verify] builds and runs as is in the browser. On amd64,
+// the purego build tag selects the same pure Go implementation.
+//
 // # See also
 //
 // https://eprint.iacr.org/2019/953
 package plonk
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io"
+	"math/big"
+	"math/rand"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/kzg"
@@ -92,10 +105,17 @@ type VerifyingKey interface {
 	gnarkio.WriterRawTo
 	gnarkio.UnsafeReaderFrom
 	NbPublicWitness() int // number of elements expected in the public witness
+	Fingerprint() []byte  // SHA-256 digest of the binary encoding
 	ExportSolidity(w io.Writer) error
+	ExportSolidityNamed(w io.Writer, names []string) error
 }
 
 // Setup prepares the public data associated to a circuit + public inputs.
+//
+// The keys don't depend on the prover options: the same proving key produces
+// proofs with or without zero-knowledge (see [backend.WithBlindingFactors]),
+// and they are all verified by the same verifying key. There is no setup for
+// non zero-knowledge proofs only.
 func Setup(ccs constraint.ConstraintSystem, kzgSrs kzg.SRS) (ProvingKey, VerifyingKey, error) {
 
 	switch tccs := ccs.(type) {
@@ -119,6 +139,72 @@ func Setup(ccs constraint.ConstraintSystem, kzgSrs kzg.SRS) (ProvingKey, Verifyi
 
 }
 
+// UpdateVK returns the verifying key of ccs, a modified version of the circuit
+// of vk, without running the full setup: the selector and permutation
+// commitments are recomputed, the proving key isn't built. It is only valid
+// when ccs has the same domain size as vk, that is when its number of
+// constraints plus its number of public inputs rounds up to the same power of
+// two, and when kzgSrs is the SRS vk was computed with; otherwise an error is
+// returned and Setup must be run again. Proofs for ccs still need the proving
+// key returned by Setup.
+func UpdateVK(vk VerifyingKey, ccs constraint.ConstraintSystem, kzgSrs kzg.SRS) (VerifyingKey, error) {
+	errCurveMismatch := errors.New("the verifying key, the constraint system and the srs must be on the same curve")
+
+	switch tccs := ccs.(type) {
+	case *cs_bn254.SparseR1CS:
+		tvk, ok := vk.(*plonk_bn254.VerifyingKey)
+		tsrs, ok2 := kzgSrs.(*kzg_bn254.SRS)
+		if !ok || !ok2 {
+			return nil, errCurveMismatch
+		}
+		return plonk_bn254.UpdateVK(tvk, tccs, *tsrs)
+	case *cs_bls12381.SparseR1CS:
+		tvk, ok := vk.(*plonk_bls12381.VerifyingKey)
+		tsrs, ok2 := kzgSrs.(*kzg_bls12381.SRS)
+		if !ok || !ok2 {
+			return nil, errCurveMismatch
+		}
+		return plonk_bls12381.UpdateVK(tvk, tccs, *tsrs)
+	case *cs_bls12377.SparseR1CS:
+		tvk, ok := vk.(*plonk_bls12377.VerifyingKey)
+		tsrs, ok2 := kzgSrs.(*kzg_bls12377.SRS)
+		if !ok || !ok2 {
+			return nil, errCurveMismatch
+		}
+		return plonk_bls12377.UpdateVK(tvk, tccs, *tsrs)
+	case *cs_bw6761.SparseR1CS:
+		tvk, ok := vk.(*plonk_bw6761.VerifyingKey)
+		tsrs, ok2 := kzgSrs.(*kzg_bw6761.SRS)
+		if !ok || !ok2 {
+			return nil, errCurveMismatch
+		}
+		return plonk_bw6761.UpdateVK(tvk, tccs, *tsrs)
+	case *cs_bls24317.SparseR1CS:
+		tvk, ok := vk.(*plonk_bls24317.VerifyingKey)
+		tsrs, ok2 := kzgSrs.(*kzg_bls24317.SRS)
+		if !ok || !ok2 {
+			return nil, errCurveMismatch
+		}
+		return plonk_bls24317.UpdateVK(tvk, tccs, *tsrs)
+	case *cs_bls24315.SparseR1CS:
+		tvk, ok := vk.(*plonk_bls24315.VerifyingKey)
+		tsrs, ok2 := kzgSrs.(*kzg_bls24315.SRS)
+		if !ok || !ok2 {
+			return nil, errCurveMismatch
+		}
+		return plonk_bls24315.UpdateVK(tvk, tccs, *tsrs)
+	case *cs_bw6633.SparseR1CS:
+		tvk, ok := vk.(*plonk_bw6633.VerifyingKey)
+		tsrs, ok2 := kzgSrs.(*kzg_bw6633.SRS)
+		if !ok || !ok2 {
+			return nil, errCurveMismatch
+		}
+		return plonk_bw6633.UpdateVK(tvk, tccs, *tsrs)
+	default:
+		return nil, errCurveMismatch
+	}
+}
+
 // Prove generates PLONK proof from a circuit, associated preprocessed public data, and the witness
 // if the force flag is set:
 //
@@ -154,6 +240,69 @@ func Prove(ccs constraint.ConstraintSystem, pk ProvingKey, fullWitness witness.W
 	}
 }
 
+// MockProve returns a proof of ccs which has the shape and the binary encoding
+// of the proofs generated by Prove, but none of their meaning: it is filled
+// with placeholder points and values, and doesn't take the time of a real
+// proof. The proof is marked as a mock one in its encoding, so that Verify
+// always rejects it. It is meant to exercise the serialization and transport
+// of proofs during development.
+func MockProve(ccs constraint.ConstraintSystem, pk ProvingKey, publicWitness witness.Witness) (Proof, error) {
+
+	switch tccs := ccs.(type) {
+	case *cs_bn254.SparseR1CS:
+		w, ok := publicWitness.Vector().(fr_bn254.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bn254.MockProve(tccs, pk.(*plonk_bn254.ProvingKey), w)
+
+	case *cs_bls12381.SparseR1CS:
+		w, ok := publicWitness.Vector().(fr_bls12381.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bls12381.MockProve(tccs, pk.(*plonk_bls12381.ProvingKey), w)
+
+	case *cs_bls12377.SparseR1CS:
+		w, ok := publicWitness.Vector().(fr_bls12377.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bls12377.MockProve(tccs, pk.(*plonk_bls12377.ProvingKey), w)
+
+	case *cs_bw6761.SparseR1CS:
+		w, ok := publicWitness.Vector().(fr_bw6761.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bw6761.MockProve(tccs, pk.(*plonk_bw6761.ProvingKey), w)
+
+	case *cs_bw6633.SparseR1CS:
+		w, ok := publicWitness.Vector().(fr_bw6633.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bw6633.MockProve(tccs, pk.(*plonk_bw6633.ProvingKey), w)
+
+	case *cs_bls24317.SparseR1CS:
+		w, ok := publicWitness.Vector().(fr_bls24317.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bls24317.MockProve(tccs, pk.(*plonk_bls24317.ProvingKey), w)
+
+	case *cs_bls24315.SparseR1CS:
+		w, ok := publicWitness.Vector().(fr_bls24315.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bls24315.MockProve(tccs, pk.(*plonk_bls24315.ProvingKey), w)
+
+	default:
+		panic("unrecognized SparseR1CS curve type")
+	}
+}
+
 // Verify verifies a PLONK proof, from the proof, preprocessed public data, and public witness.
 func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness) error {
 
@@ -213,6 +362,902 @@ func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness) error {
 	}
 }
 
+// VerifyAgainstFingerprint verifies proof with the verifying key returned by
+// fetch, after checking that its fingerprint (see VerifyingKey.Fingerprint) is
+// fingerprint. It is meant for the verifiers which only store the fingerprints
+// of the verifying keys, and load them lazily from an untrusted source: a key
+// swapped for another one is rejected before any verification work.
+func VerifyAgainstFingerprint(proof Proof, fingerprint []byte, publicWitness witness.Witness, fetch func([]byte) (VerifyingKey, error)) error {
+	vk, err := fetch(fingerprint)
+	if err != nil {
+		return fmt.Errorf("fetch verifying key: %w", err)
+	}
+	if !bytes.Equal(vk.Fingerprint(), fingerprint) {
+		return errors.New("the fingerprint of the fetched verifying key doesn't match")
+	}
+	return Verify(proof, vk, publicWitness)
+}
+
+// VerifyWithBinding verifies a PLONK proof bound to tag with the
+// backend.WithProofBinding prover option. It fails if the proof was bound to a
+// different tag, or wasn't bound to any.
+func VerifyWithBinding(proof Proof, vk VerifyingKey, publicWitness witness.Witness, tag []byte) error {
+
+	switch _proof := proof.(type) {
+
+	case *plonk_bn254.Proof:
+		w, ok := publicWitness.Vector().(fr_bn254.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return plonk_bn254.VerifyWithBinding(_proof, vk.(*plonk_bn254.VerifyingKey), w, tag)
+
+	case *plonk_bls12381.Proof:
+		w, ok := publicWitness.Vector().(fr_bls12381.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return plonk_bls12381.VerifyWithBinding(_proof, vk.(*plonk_bls12381.VerifyingKey), w, tag)
+
+	case *plonk_bls12377.Proof:
+		w, ok := publicWitness.Vector().(fr_bls12377.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return plonk_bls12377.VerifyWithBinding(_proof, vk.(*plonk_bls12377.VerifyingKey), w, tag)
+
+	case *plonk_bw6761.Proof:
+		w, ok := publicWitness.Vector().(fr_bw6761.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return plonk_bw6761.VerifyWithBinding(_proof, vk.(*plonk_bw6761.VerifyingKey), w, tag)
+
+	case *plonk_bw6633.Proof:
+		w, ok := publicWitness.Vector().(fr_bw6633.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return plonk_bw6633.VerifyWithBinding(_proof, vk.(*plonk_bw6633.VerifyingKey), w, tag)
+
+	case *plonk_bls24317.Proof:
+		w, ok := publicWitness.Vector().(fr_bls24317.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return plonk_bls24317.VerifyWithBinding(_proof, vk.(*plonk_bls24317.VerifyingKey), w, tag)
+
+	case *plonk_bls24315.Proof:
+		w, ok := publicWitness.Vector().(fr_bls24315.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return plonk_bls24315.VerifyWithBinding(_proof, vk.(*plonk_bls24315.VerifyingKey), w, tag)
+
+	default:
+		panic("unrecognized proof type")
+	}
+}
+
+// PairingInputs are the inputs of the pairing check which completes the
+// verification of a proof, as returned by PrepareVerify.
+//
+// it's underlying implementation is curve specific (see gnark/internal/backend)
+type PairingInputs interface {
+	Curve() ecc.ID
+}
+
+// PrepareVerify performs all the checks of Verify but the final pairing check,
+// and returns the pairs of points (G1, G2) whose product of pairings must be
+// the identity of the target group for the proof to be valid. It allows an
+// external component, such as an HSM, to complete the verification; FinishVerify
+// does it with gnark-crypto.
+//
+// The underlying type of the inputs is the PairingInputs type of the package of
+// the curve of the proof, e.g. *plonk_bn254.PairingInputs.
+func PrepareVerify(proof Proof, vk VerifyingKey, publicWitness witness.Witness) (PairingInputs, error) {
+	switch _proof := proof.(type) {
+	case *plonk_bn254.Proof:
+		w, ok := publicWitness.Vector().(fr_bn254.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bn254.PrepareVerify(_proof, vk.(*plonk_bn254.VerifyingKey), w)
+
+	case *plonk_bls12381.Proof:
+		w, ok := publicWitness.Vector().(fr_bls12381.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bls12381.PrepareVerify(_proof, vk.(*plonk_bls12381.VerifyingKey), w)
+
+	case *plonk_bls12377.Proof:
+		w, ok := publicWitness.Vector().(fr_bls12377.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bls12377.PrepareVerify(_proof, vk.(*plonk_bls12377.VerifyingKey), w)
+
+	case *plonk_bw6761.Proof:
+		w, ok := publicWitness.Vector().(fr_bw6761.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bw6761.PrepareVerify(_proof, vk.(*plonk_bw6761.VerifyingKey), w)
+
+	case *plonk_bw6633.Proof:
+		w, ok := publicWitness.Vector().(fr_bw6633.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bw6633.PrepareVerify(_proof, vk.(*plonk_bw6633.VerifyingKey), w)
+
+	case *plonk_bls24317.Proof:
+		w, ok := publicWitness.Vector().(fr_bls24317.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bls24317.PrepareVerify(_proof, vk.(*plonk_bls24317.VerifyingKey), w)
+
+	case *plonk_bls24315.Proof:
+		w, ok := publicWitness.Vector().(fr_bls24315.Vector)
+		if !ok {
+			return nil, witness.ErrInvalidWitness
+		}
+		return plonk_bls24315.PrepareVerify(_proof, vk.(*plonk_bls24315.VerifyingKey), w)
+
+	default:
+		panic("unrecognized proof type")
+	}
+}
+
+// FinishVerify performs the pairing check returned by PrepareVerify, and
+// returns an error if it fails.
+func FinishVerify(inputs PairingInputs) error {
+	switch _inputs := inputs.(type) {
+	case *plonk_bn254.PairingInputs:
+		return plonk_bn254.FinishVerify(_inputs)
+	case *plonk_bls12381.PairingInputs:
+		return plonk_bls12381.FinishVerify(_inputs)
+	case *plonk_bls12377.PairingInputs:
+		return plonk_bls12377.FinishVerify(_inputs)
+	case *plonk_bw6761.PairingInputs:
+		return plonk_bw6761.FinishVerify(_inputs)
+	case *plonk_bw6633.PairingInputs:
+		return plonk_bw6633.FinishVerify(_inputs)
+	case *plonk_bls24317.PairingInputs:
+		return plonk_bls24317.FinishVerify(_inputs)
+	case *plonk_bls24315.PairingInputs:
+		return plonk_bls24315.FinishVerify(_inputs)
+	default:
+		panic("unrecognized pairing inputs type")
+	}
+}
+
+// VerifyAny checks proof against each of the candidate verifying keys, in
+// order, and returns the index of the first one which accepts it. A proof
+// embedding its domain size is only checked against the keys of a circuit of
+// the same size, which skips the verification work for most of the other keys.
+// It returns an error if no key verifies the proof.
+func VerifyAny(proof Proof, vks []VerifyingKey, publicWitness witness.Witness) (int, error) {
+	for i, vk := range vks {
+		if !domainSizeMatches(proof, vk) {
+			continue
+		}
+		if err := Verify(proof, vk, publicWitness); err == nil {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("proof is not verified by any of the %d verifying keys", len(vks))
+}
+
+// LinkConstraint states that two public inputs of the proofs passed to
+// VerifyLinked are equal: the public input FromInput of the proof FromProof,
+// and the public input ToInput of the proof ToProof. The proofs are indexed in
+// the order they are passed, and the public inputs in the order of the public
+// witnesses.
+type LinkConstraint struct {
+	FromProof, FromInput int
+	ToProof, ToInput     int
+}
+
+// VerifyLinked verifies proofs[i] against vks[i] and publics[i] for each i, as
+// Verify does, and checks that the public inputs related by links are equal.
+// It is meant for a computation split across several circuits, the outputs of
+// one being the inputs of another. The links are checked first, as they are
+// much cheaper than the proofs.
+func VerifyLinked(proofs []Proof, vks []VerifyingKey, publics []witness.Witness, links []LinkConstraint) error {
+	if len(proofs) != len(vks) || len(proofs) != len(publics) {
+		return fmt.Errorf("got %d proofs, %d verifying keys and %d public witnesses", len(proofs), len(vks), len(publics))
+	}
+	for _, l := range links {
+		if l.FromProof < 0 || l.FromProof >= len(proofs) || l.ToProof < 0 || l.ToProof >= len(proofs) {
+			return fmt.Errorf("link %+v: proof index out of range", l)
+		}
+		from, err := publicInput(publics[l.FromProof], l.FromInput)
+		if err != nil {
+			return fmt.Errorf("link %+v: %w", l, err)
+		}
+		to, err := publicInput(publics[l.ToProof], l.ToInput)
+		if err != nil {
+			return fmt.Errorf("link %+v: %w", l, err)
+		}
+		if !bytes.Equal(from, to) {
+			return fmt.Errorf("link %+v: public inputs differ", l)
+		}
+	}
+	for i := range proofs {
+		if err := Verify(proofs[i], vks[i], publics[i]); err != nil {
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// publicInput returns the big-endian encoding of the public input i of w.
+func publicInput(w witness.Witness, i int) ([]byte, error) {
+	if i < 0 {
+		return nil, fmt.Errorf("public input %d out of range", i)
+	}
+	switch v := w.Vector().(type) {
+	case fr_bn254.Vector:
+		if i < len(v) {
+			return v[i].Marshal(), nil
+		}
+	case fr_bls12381.Vector:
+		if i < len(v) {
+			return v[i].Marshal(), nil
+		}
+	case fr_bls12377.Vector:
+		if i < len(v) {
+			return v[i].Marshal(), nil
+		}
+	case fr_bw6761.Vector:
+		if i < len(v) {
+			return v[i].Marshal(), nil
+		}
+	case fr_bw6633.Vector:
+		if i < len(v) {
+			return v[i].Marshal(), nil
+		}
+	case fr_bls24317.Vector:
+		if i < len(v) {
+			return v[i].Marshal(), nil
+		}
+	case fr_bls24315.Vector:
+		if i < len(v) {
+			return v[i].Marshal(), nil
+		}
+	default:
+		return nil, witness.ErrInvalidWitness
+	}
+	return nil, fmt.Errorf("public input %d out of range", i)
+}
+
+// ProofsEqual reports whether a and b are the same proof, that is whether
+// their commitments, opening proofs and claimed values are equal, regardless of
+// the encoding (compressed or not, legacy or versioned) they may have been read
+// from. Proofs on different curves are never equal.
+func ProofsEqual(a, b Proof) bool {
+	switch _a := a.(type) {
+	case *plonk_bn254.Proof:
+		_b, ok := b.(*plonk_bn254.Proof)
+		return ok && _a.Equal(_b)
+	case *plonk_bls12381.Proof:
+		_b, ok := b.(*plonk_bls12381.Proof)
+		return ok && _a.Equal(_b)
+	case *plonk_bls12377.Proof:
+		_b, ok := b.(*plonk_bls12377.Proof)
+		return ok && _a.Equal(_b)
+	case *plonk_bw6761.Proof:
+		_b, ok := b.(*plonk_bw6761.Proof)
+		return ok && _a.Equal(_b)
+	case *plonk_bw6633.Proof:
+		_b, ok := b.(*plonk_bw6633.Proof)
+		return ok && _a.Equal(_b)
+	case *plonk_bls24317.Proof:
+		_b, ok := b.(*plonk_bls24317.Proof)
+		return ok && _a.Equal(_b)
+	case *plonk_bls24315.Proof:
+		_b, ok := b.(*plonk_bls24315.Proof)
+		return ok && _a.Equal(_b)
+	default:
+		panic("unrecognized proof type")
+	}
+}
+
+// domainSizeMatches returns false when vk is not a key of the curve of proof,
+// or when proof embeds a domain size different from the one of vk.
+func domainSizeMatches(proof Proof, vk VerifyingKey) bool {
+	switch _proof := proof.(type) {
+	case *plonk_bn254.Proof:
+		_vk, ok := vk.(*plonk_bn254.VerifyingKey)
+		return ok && (_proof.DomainSize == 0 || _proof.DomainSize == _vk.Size)
+	case *plonk_bls12381.Proof:
+		_vk, ok := vk.(*plonk_bls12381.VerifyingKey)
+		return ok && (_proof.DomainSize == 0 || _proof.DomainSize == _vk.Size)
+	case *plonk_bls12377.Proof:
+		_vk, ok := vk.(*plonk_bls12377.VerifyingKey)
+		return ok && (_proof.DomainSize == 0 || _proof.DomainSize == _vk.Size)
+	case *plonk_bw6761.Proof:
+		_vk, ok := vk.(*plonk_bw6761.VerifyingKey)
+		return ok && (_proof.DomainSize == 0 || _proof.DomainSize == _vk.Size)
+	case *plonk_bw6633.Proof:
+		_vk, ok := vk.(*plonk_bw6633.VerifyingKey)
+		return ok && (_proof.DomainSize == 0 || _proof.DomainSize == _vk.Size)
+	case *plonk_bls24317.Proof:
+		_vk, ok := vk.(*plonk_bls24317.VerifyingKey)
+		return ok && (_proof.DomainSize == 0 || _proof.DomainSize == _vk.Size)
+	case *plonk_bls24315.Proof:
+		_vk, ok := vk.(*plonk_bls24315.VerifyingKey)
+		return ok && (_proof.DomainSize == 0 || _proof.DomainSize == _vk.Size)
+	default:
+		panic("unrecognized proof type")
+	}
+}
+
+// VerifyStats reports the time spent in each phase of the verification of a
+// proof, see VerifyInstrumented.
+type VerifyStats struct {
+	Validation time.Duration // checks of the shape of the proof against vk
+	Challenges time.Duration // derivation of the Fiat-Shamir challenges
+	MSM        time.Duration // reduction of the proof to KZG opening claims
+	Pairing    time.Duration // pairing check of the opening claims
+}
+
+// VerifyInstrumented verifies proof as Verify does, and returns the time spent
+// in each phase of the verification. The stats of the phases performed are
+// returned even if the verification fails. The points of the proof are
+// validated when it is deserialized, which is not included.
+func VerifyInstrumented(proof Proof, vk VerifyingKey, publicWitness witness.Witness) (VerifyStats, error) {
+
+	switch _proof := proof.(type) {
+
+	case *plonk_bn254.Proof:
+		w, ok := publicWitness.Vector().(fr_bn254.Vector)
+		if !ok {
+			return VerifyStats{}, witness.ErrInvalidWitness
+		}
+		stats, err := plonk_bn254.VerifyInstrumented(_proof, vk.(*plonk_bn254.VerifyingKey), w)
+		return VerifyStats(stats), err
+
+	case *plonk_bls12381.Proof:
+		w, ok := publicWitness.Vector().(fr_bls12381.Vector)
+		if !ok {
+			return VerifyStats{}, witness.ErrInvalidWitness
+		}
+		stats, err := plonk_bls12381.VerifyInstrumented(_proof, vk.(*plonk_bls12381.VerifyingKey), w)
+		return VerifyStats(stats), err
+
+	case *plonk_bls12377.Proof:
+		w, ok := publicWitness.Vector().(fr_bls12377.Vector)
+		if !ok {
+			return VerifyStats{}, witness.ErrInvalidWitness
+		}
+		stats, err := plonk_bls12377.VerifyInstrumented(_proof, vk.(*plonk_bls12377.VerifyingKey), w)
+		return VerifyStats(stats), err
+
+	case *plonk_bw6761.Proof:
+		w, ok := publicWitness.Vector().(fr_bw6761.Vector)
+		if !ok {
+			return VerifyStats{}, witness.ErrInvalidWitness
+		}
+		stats, err := plonk_bw6761.VerifyInstrumented(_proof, vk.(*plonk_bw6761.VerifyingKey), w)
+		return VerifyStats(stats), err
+
+	case *plonk_bw6633.Proof:
+		w, ok := publicWitness.Vector().(fr_bw6633.Vector)
+		if !ok {
+			return VerifyStats{}, witness.ErrInvalidWitness
+		}
+		stats, err := plonk_bw6633.VerifyInstrumented(_proof, vk.(*plonk_bw6633.VerifyingKey), w)
+		return VerifyStats(stats), err
+
+	case *plonk_bls24317.Proof:
+		w, ok := publicWitness.Vector().(fr_bls24317.Vector)
+		if !ok {
+			return VerifyStats{}, witness.ErrInvalidWitness
+		}
+		stats, err := plonk_bls24317.VerifyInstrumented(_proof, vk.(*plonk_bls24317.VerifyingKey), w)
+		return VerifyStats(stats), err
+
+	case *plonk_bls24315.Proof:
+		w, ok := publicWitness.Vector().(fr_bls24315.Vector)
+		if !ok {
+			return VerifyStats{}, witness.ErrInvalidWitness
+		}
+		stats, err := plonk_bls24315.VerifyInstrumented(_proof, vk.(*plonk_bls24315.VerifyingKey), w)
+		return VerifyStats(stats), err
+
+	default:
+		panic("unrecognized proof type")
+	}
+}
+
+// BatchVerifier verifies a stream of PLONK proofs for the same circuit with a
+// single final pairing check, without holding the proofs in memory.
+type BatchVerifier interface {
+	// Add checks a proof up to the pairings and folds it into the batch.
+	Add(proof Proof, publicWitness witness.Witness) error
+
+	// Verify checks all the proofs added so far.
+	Verify() error
+}
+
+// NewBatchVerifier returns a BatchVerifier for proofs verified against vk.
+func NewBatchVerifier(vk VerifyingKey) BatchVerifier {
+
+	switch _vk := vk.(type) {
+	case *plonk_bn254.VerifyingKey:
+		return &batchVerifier{plonk_bn254.NewBatchVerifier(_vk)}
+	case *plonk_bls12381.VerifyingKey:
+		return &batchVerifier{plonk_bls12381.NewBatchVerifier(_vk)}
+	case *plonk_bls12377.VerifyingKey:
+		return &batchVerifier{plonk_bls12377.NewBatchVerifier(_vk)}
+	case *plonk_bw6761.VerifyingKey:
+		return &batchVerifier{plonk_bw6761.NewBatchVerifier(_vk)}
+	case *plonk_bls24317.VerifyingKey:
+		return &batchVerifier{plonk_bls24317.NewBatchVerifier(_vk)}
+	case *plonk_bls24315.VerifyingKey:
+		return &batchVerifier{plonk_bls24315.NewBatchVerifier(_vk)}
+	case *plonk_bw6633.VerifyingKey:
+		return &batchVerifier{plonk_bw6633.NewBatchVerifier(_vk)}
+	default:
+		panic("unrecognized verifying key type")
+	}
+}
+
+type batchVerifier struct {
+	inner interface{ Verify() error }
+}
+
+func (bv *batchVerifier) Add(proof Proof, publicWitness witness.Witness) error {
+
+	switch v := bv.inner.(type) {
+	case *plonk_bn254.BatchVerifier:
+		_proof, ok := proof.(*plonk_bn254.Proof)
+		if !ok {
+			return errors.New("proof and verifying key are not on the same curve")
+		}
+		w, ok := publicWitness.Vector().(fr_bn254.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return v.Add(_proof, w)
+	case *plonk_bls12381.BatchVerifier:
+		_proof, ok := proof.(*plonk_bls12381.Proof)
+		if !ok {
+			return errors.New("proof and verifying key are not on the same curve")
+		}
+		w, ok := publicWitness.Vector().(fr_bls12381.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return v.Add(_proof, w)
+	case *plonk_bls12377.BatchVerifier:
+		_proof, ok := proof.(*plonk_bls12377.Proof)
+		if !ok {
+			return errors.New("proof and verifying key are not on the same curve")
+		}
+		w, ok := publicWitness.Vector().(fr_bls12377.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return v.Add(_proof, w)
+	case *plonk_bw6761.BatchVerifier:
+		_proof, ok := proof.(*plonk_bw6761.Proof)
+		if !ok {
+			return errors.New("proof and verifying key are not on the same curve")
+		}
+		w, ok := publicWitness.Vector().(fr_bw6761.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return v.Add(_proof, w)
+	case *plonk_bls24317.BatchVerifier:
+		_proof, ok := proof.(*plonk_bls24317.Proof)
+		if !ok {
+			return errors.New("proof and verifying key are not on the same curve")
+		}
+		w, ok := publicWitness.Vector().(fr_bls24317.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return v.Add(_proof, w)
+	case *plonk_bls24315.BatchVerifier:
+		_proof, ok := proof.(*plonk_bls24315.Proof)
+		if !ok {
+			return errors.New("proof and verifying key are not on the same curve")
+		}
+		w, ok := publicWitness.Vector().(fr_bls24315.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return v.Add(_proof, w)
+	case *plonk_bw6633.BatchVerifier:
+		_proof, ok := proof.(*plonk_bw6633.Proof)
+		if !ok {
+			return errors.New("proof and verifying key are not on the same curve")
+		}
+		w, ok := publicWitness.Vector().(fr_bw6633.Vector)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		return v.Add(_proof, w)
+	default:
+		panic("unrecognized batch verifier type")
+	}
+}
+
+func (bv *batchVerifier) Verify() error {
+	return bv.inner.Verify()
+}
+
+// BatchVerify verifies proofs against vk, where proofs[i] is a proof for the
+// public inputs publicWitnesses[i]. The proofs share the verifying key and
+// their KZG openings are combined with random coefficients into a single
+// pairing check, as with a BatchVerifier.
+//
+// All the proofs and public witnesses are first checked to be on the curve of
+// vk, and witness.ErrInvalidWitness is returned otherwise. When the batch is
+// invalid, the returned error identifies the index of the first invalid proof:
+// the proofs rejected before the pairing check are reported as they are added,
+// and if the final pairing check fails the proofs are verified one by one to
+// find the culprit.
+func BatchVerify(proofs []Proof, vk VerifyingKey, publicWitnesses []witness.Witness) error {
+	if len(proofs) != len(publicWitnesses) {
+		return fmt.Errorf("got %d proofs and %d public witnesses", len(proofs), len(publicWitnesses))
+	}
+	if len(proofs) == 0 {
+		return errors.New("no proof to verify")
+	}
+	for i := range proofs {
+		if !onCurveOf(vk, proofs[i], publicWitnesses[i]) {
+			return fmt.Errorf("proof %d: %w", i, witness.ErrInvalidWitness)
+		}
+	}
+
+	// firstInvalid returns the error of the first invalid proof among the n
+	// first ones, verified on their own, or nil if they are all valid.
+	firstInvalid := func(n int) error {
+		for i := 0; i < n; i++ {
+			if err := Verify(proofs[i], vk, publicWitnesses[i]); err != nil {
+				return fmt.Errorf("proof %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+
+	bv := NewBatchVerifier(vk)
+	for i := range proofs {
+		if err := bv.Add(proofs[i], publicWitnesses[i]); err != nil {
+			// a proof added before may be invalid as well, which only the
+			// final pairing check would have caught
+			if errPrev := firstInvalid(i); errPrev != nil {
+				return errPrev
+			}
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+	}
+	if err := bv.Verify(); err == nil {
+		return nil
+	}
+
+	// isolate the first invalid proof. If every proof is valid on its own,
+	// the random coefficients of the batch were unlucky, which only happens
+	// with negligible probability.
+	return firstInvalid(len(proofs))
+}
+
+// onCurveOf reports whether proof and publicWitness are on the curve of vk.
+func onCurveOf(vk VerifyingKey, proof Proof, publicWitness witness.Witness) bool {
+	if publicWitness == nil {
+		return false
+	}
+	switch vk.(type) {
+	case *plonk_bn254.VerifyingKey:
+		_, okProof := proof.(*plonk_bn254.Proof)
+		_, okWitness := publicWitness.Vector().(fr_bn254.Vector)
+		return okProof && okWitness
+	case *plonk_bls12381.VerifyingKey:
+		_, okProof := proof.(*plonk_bls12381.Proof)
+		_, okWitness := publicWitness.Vector().(fr_bls12381.Vector)
+		return okProof && okWitness
+	case *plonk_bls12377.VerifyingKey:
+		_, okProof := proof.(*plonk_bls12377.Proof)
+		_, okWitness := publicWitness.Vector().(fr_bls12377.Vector)
+		return okProof && okWitness
+	case *plonk_bw6761.VerifyingKey:
+		_, okProof := proof.(*plonk_bw6761.Proof)
+		_, okWitness := publicWitness.Vector().(fr_bw6761.Vector)
+		return okProof && okWitness
+	case *plonk_bls24317.VerifyingKey:
+		_, okProof := proof.(*plonk_bls24317.Proof)
+		_, okWitness := publicWitness.Vector().(fr_bls24317.Vector)
+		return okProof && okWitness
+	case *plonk_bls24315.VerifyingKey:
+		_, okProof := proof.(*plonk_bls24315.Proof)
+		_, okWitness := publicWitness.Vector().(fr_bls24315.Vector)
+		return okProof && okWitness
+	case *plonk_bw6633.VerifyingKey:
+		_, okProof := proof.(*plonk_bw6633.Proof)
+		_, okWitness := publicWitness.Vector().(fr_bw6633.Vector)
+		return okProof && okWitness
+	default:
+		return false
+	}
+}
+
+// EncodeCalldata returns the calldata of a call to the Verify function of the
+// Solidity verifier exported by VerifyingKey.ExportSolidity, checking proof
+// against publicWitness. Only BN254 has a Solidity verifier.
+func EncodeCalldata(proof Proof, publicWitness witness.Witness) ([]byte, error) {
+	_proof, ok := proof.(*plonk_bn254.Proof)
+	if !ok {
+		return nil, fmt.Errorf("no Solidity verifier on curve %s, only on %s", proofCurve(proof), ecc.BN254)
+	}
+	w, ok := publicWitness.Vector().(fr_bn254.Vector)
+	if !ok {
+		return nil, witness.ErrInvalidWitness
+	}
+	return _proof.EncodeCalldata(w), nil
+}
+
+// proofCurve returns the curve of proof, or ecc.UNKNOWN.
+func proofCurve(proof Proof) ecc.ID {
+	switch proof.(type) {
+	case *plonk_bn254.Proof:
+		return ecc.BN254
+	case *plonk_bls12381.Proof:
+		return ecc.BLS12_381
+	case *plonk_bls12377.Proof:
+		return ecc.BLS12_377
+	case *plonk_bw6761.Proof:
+		return ecc.BW6_761
+	case *plonk_bls24317.Proof:
+		return ecc.BLS24_317
+	case *plonk_bls24315.Proof:
+		return ecc.BLS24_315
+	case *plonk_bw6633.Proof:
+		return ecc.BW6_633
+	default:
+		return ecc.UNKNOWN
+	}
+}
+
+// Metrics describes the cost of the proofs of a circuit.
+type Metrics struct {
+	NbPublicInputs int    // number of elements of the public witness
+	ProofSize      int    // size in bytes of the binary encoding of a proof
+	SolidityGas    uint64 // estimated gas of the Solidity verifier, 0 if there is none
+}
+
+// CircuitMetrics returns the metrics of the circuit of vk. They are computed
+// from vk only, without proving or verifying. The Solidity verifier is only
+// available on BN254, see VerifyingKey.ExportSolidity.
+func CircuitMetrics(vk VerifyingKey) Metrics {
+	m := Metrics{
+		NbPublicInputs: vk.NbPublicWitness(),
+		ProofSize:      proofSize(vk),
+	}
+	if _vk, ok := vk.(*plonk_bn254.VerifyingKey); ok {
+		m.SolidityGas = _vk.EstimateSolidityGas()
+	}
+	return m
+}
+
+// proofSize returns the size in bytes of the binary encoding of the proofs
+// verified with vk.
+func proofSize(vk VerifyingKey) int {
+	switch _vk := vk.(type) {
+	case *plonk_bn254.VerifyingKey:
+		return _vk.ProofSize()
+	case *plonk_bls12381.VerifyingKey:
+		return _vk.ProofSize()
+	case *plonk_bls12377.VerifyingKey:
+		return _vk.ProofSize()
+	case *plonk_bw6761.VerifyingKey:
+		return _vk.ProofSize()
+	case *plonk_bw6633.VerifyingKey:
+		return _vk.ProofSize()
+	case *plonk_bls24317.VerifyingKey:
+		return _vk.ProofSize()
+	case *plonk_bls24315.VerifyingKey:
+		return _vk.ProofSize()
+	default:
+		panic("unrecognized verifying key type")
+	}
+}
+
+// QuickInfeasibilityCheck reports whether no assignment of the secret inputs
+// can satisfy ccs for the given public inputs, as a fast fail before running
+// the solver.
+//
+// The check is best-effort: the values of the public inputs are propagated
+// through the constraints, and a wire is deduced when it is the only unknown
+// of a constraint in which it appears linearly. It returns true only when a
+// constraint whose wires are all deduced doesn't hold, which is a definite
+// contradiction. It returns false otherwise, which doesn't mean that the
+// circuit is satisfiable: the solver may still fail, for example on an
+// assertion involving the secret inputs.
+func QuickInfeasibilityCheck(ccs constraint.ConstraintSystem, publicWitness witness.Witness) (bool, error) {
+	spr, ok := ccs.(constraint.SparseR1CS)
+	if !ok {
+		return false, errors.New("expected a SparseR1CS")
+	}
+	nbPublic := ccs.GetNbPublicVariables()
+	if _, err := publicInput(publicWitness, nbPublic); err == nil {
+		return false, fmt.Errorf("expected a public witness of %d elements", nbPublic)
+	}
+
+	// the public inputs are the first wires
+	known := make(map[uint32]constraint.Element, nbPublic)
+	for i := 0; i < nbPublic; i++ {
+		b, err := publicInput(publicWitness, i)
+		if err != nil {
+			return false, err
+		}
+		known[uint32(i)] = ccs.FromInterface(new(big.Int).SetBytes(b))
+	}
+
+	// the constraints are mostly in solving order: the propagation goes through
+	// them again as long as new wires are deduced.
+	constraints := spr.GetSparseR1Cs()
+	done := make([]bool, len(constraints))
+	for progress := true; progress; {
+		progress = false
+		for i := range constraints {
+			if done[i] {
+				continue
+			}
+			deduced, contradiction := propagate(ccs, &constraints[i], known)
+			if contradiction {
+				return true, nil
+			}
+			if deduced {
+				done[i] = true
+				progress = true
+			}
+		}
+	}
+	return false, nil
+}
+
+// propagate evaluates qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xaxb) + qC == 0 on the known
+// wires. If all the wires are known, it reports whether the constraint doesn't
+// hold. If a single wire is unknown and appears linearly, its value is added
+// to known. deduced is true when the constraint brings no further information.
+func propagate(ccs constraint.ConstraintSystem, c *constraint.SparseR1C, known map[uint32]constraint.Element) (deduced, contradiction bool) {
+	constant := ccs.GetCoefficient(int(c.QC))
+	unknowns := make(map[uint32]constraint.Element, 2)
+	addTerm := func(coeff constraint.Element, wire uint32) {
+		if coeff.IsZero() {
+			return
+		}
+		if v, ok := known[wire]; ok {
+			constant = ccs.Add(constant, ccs.Mul(coeff, v))
+		} else {
+			unknowns[wire] = ccs.Add(unknowns[wire], coeff)
+		}
+	}
+	addTerm(ccs.GetCoefficient(int(c.QL)), c.XA)
+	addTerm(ccs.GetCoefficient(int(c.QR)), c.XB)
+	addTerm(ccs.GetCoefficient(int(c.QO)), c.XC)
+	if qM := ccs.GetCoefficient(int(c.QM)); !qM.IsZero() {
+		a, aKnown := known[c.XA]
+		b, bKnown := known[c.XB]
+		switch {
+		case aKnown:
+			addTerm(ccs.Mul(qM, a), c.XB)
+		case bKnown:
+			addTerm(ccs.Mul(qM, b), c.XA)
+		default:
+			// quadratic in the unknown wires
+			return false, false
+		}
+	}
+
+	var wire uint32
+	var coeff constraint.Element
+	nbUnknowns := 0
+	for w, k := range unknowns {
+		if !k.IsZero() {
+			wire, coeff = w, k
+			nbUnknowns++
+		}
+	}
+	switch nbUnknowns {
+	case 0:
+		return true, !constant.IsZero()
+	case 1:
+		inv, _ := ccs.Inverse(coeff)
+		known[wire] = ccs.Neg(ccs.Mul(constant, inv))
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+// ErrConstrainedInputs is returned by RandomUnconstrainedWitness when random
+// inputs don't solve the constraint system.
+var ErrConstrainedInputs = errors.New("random inputs don't solve the constraint system")
+
+// RandomUnconstrainedWitness returns a full witness of ccs whose public and
+// secret inputs are drawn at random from a generator seeded with seed, so that
+// the same seed gives the same witness. The internal wires are computed by the
+// solver when proving.
+//
+// It is a helper for circuits with unconstrained inputs only, that is circuits
+// which any assignment of the inputs satisfies, such as circuits computing
+// hints or public outputs from their inputs. It doesn't solve for inputs
+// satisfying the assertions of a circuit: if the random inputs don't solve
+// ccs, ErrConstrainedInputs is returned, and fuzzing the circuit needs a
+// hand-written generator of assignments.
+func RandomUnconstrainedWitness(ccs constraint.ConstraintSystem, seed int64) (witness.Witness, error) {
+	if _, ok := ccs.(constraint.SparseR1CS); !ok {
+		return nil, errors.New("expected a SparseR1CS")
+	}
+	w, err := witness.New(ccs.Field())
+	if err != nil {
+		return nil, err
+	}
+
+	nbPublic, nbSecret := ccs.GetNbPublicVariables(), ccs.GetNbSecretVariables()
+	rnd := rand.New(rand.NewSource(seed)) //#nosec G404 -- reproducible test inputs
+	values := make(chan any, nbPublic+nbSecret)
+	for i := 0; i < nbPublic+nbSecret; i++ {
+		values <- new(big.Int).Rand(rnd, ccs.Field())
+	}
+	close(values)
+	if err := w.Fill(nbPublic, nbSecret, values); err != nil {
+		return nil, err
+	}
+
+	if err := ccs.IsSolved(w); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConstrainedInputs, err)
+	}
+	return w, nil
+}
+
+// SetupTest runs Setup for ccs with an SRS derived deterministically from
+// seed, so that tests get a reproducible pk/vk pair without SRS boilerplate.
+//
+// The secret of the SRS is computed from the seed: anyone knowing the seed can
+// forge proofs. SetupTest must only be used in tests, never to produce keys of
+// a deployed circuit.
+func SetupTest(ccs constraint.ConstraintSystem, seed int64) (ProvingKey, VerifyingKey, error) {
+	sizeSystem := ccs.GetNbConstraints() + ccs.GetNbPublicVariables()
+	kzgSize := ecc.NextPowerOfTwo(uint64(sizeSystem)) + 3
+
+	rnd := rand.New(rand.NewSource(seed)) //#nosec G404 -- insecure by design, test only
+	alpha := new(big.Int).Rand(rnd, ccs.Field())
+
+	var srs kzg.SRS
+	var err error
+	switch ccs.(type) {
+	case *cs_bn254.SparseR1CS:
+		srs, err = kzg_bn254.NewSRS(kzgSize, alpha)
+	case *cs_bls12381.SparseR1CS:
+		srs, err = kzg_bls12381.NewSRS(kzgSize, alpha)
+	case *cs_bls12377.SparseR1CS:
+		srs, err = kzg_bls12377.NewSRS(kzgSize, alpha)
+	case *cs_bw6761.SparseR1CS:
+		srs, err = kzg_bw6761.NewSRS(kzgSize, alpha)
+	case *cs_bls24317.SparseR1CS:
+		srs, err = kzg_bls24317.NewSRS(kzgSize, alpha)
+	case *cs_bls24315.SparseR1CS:
+		srs, err = kzg_bls24315.NewSRS(kzgSize, alpha)
+	case *cs_bw6633.SparseR1CS:
+		srs, err = kzg_bw6633.NewSRS(kzgSize, alpha)
+	default:
+		panic("unrecognized SparseR1CS curve type")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return Setup(ccs, srs)
+}
+
 // NewCS instantiate a concrete curved-typed SparseR1CS and return a ConstraintSystem interface
 // This method exists for (de)serialization purposes
 func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
@@ -290,6 +1335,31 @@ func NewProof(curveID ecc.ID) Proof {
 	return proof
 }
 
+// ProofStructure returns the number of G1 points, G2 points and scalars of a
+// PLONK proof on the curve curveID, for a circuit without BSB22 commitments
+// (e.g. without lookups nor api.Commit). Each such commitment adds one G1 point
+// and one scalar to the proof. A PLONK proof has no G2 point.
+func ProofStructure(curveID ecc.ID) (nbG1, nbG2, nbScalars int) {
+	switch curveID {
+	case ecc.BN254:
+		return plonk_bn254.ProofStructure()
+	case ecc.BLS12_377:
+		return plonk_bls12377.ProofStructure()
+	case ecc.BLS12_381:
+		return plonk_bls12381.ProofStructure()
+	case ecc.BW6_761:
+		return plonk_bw6761.ProofStructure()
+	case ecc.BLS24_317:
+		return plonk_bls24317.ProofStructure()
+	case ecc.BLS24_315:
+		return plonk_bls24315.ProofStructure()
+	case ecc.BW6_633:
+		return plonk_bw6633.ProofStructure()
+	default:
+		panic("not implemented")
+	}
+}
+
 // NewVerifyingKey instantiates a curve-typed VerifyingKey and returns an interface
 // This function exists for serialization purposes
 func NewVerifyingKey(curveID ecc.ID) VerifyingKey {
@@ -2,16 +2,28 @@ package plonk_test
 
 import (
 	"bytes"
+	"errors"
 	"math/big"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/consensys/gnark"
 	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/fft"
+	kzg_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/kzg"
 	"github.com/consensys/gnark-crypto/kzg"
+	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/plonk"
+	plonk_bn254 "github.com/consensys/gnark/backend/plonk/bn254"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
 	"github.com/consensys/gnark/test"
 	"github.com/stretchr/testify/require"
 )
@@ -58,6 +70,916 @@ func TestProver(t *testing.T) {
 	}
 }
 
+// fftProvider is an external FFT backend relying on gnark-crypto, counting
+// the number of transforms it performs.
+type fftProvider struct {
+	nbCalls atomic.Int64
+}
+
+func (p *fftProvider) FFT(v any, coset bool) {
+	p.transform(v, coset, false)
+}
+
+func (p *fftProvider) FFTInverse(v any, coset bool) {
+	p.transform(v, coset, true)
+}
+
+func (p *fftProvider) transform(v any, coset, inverse bool) {
+	p.nbCalls.Add(1)
+	a := v.(fr.Vector)
+	domain := fft.NewDomain(uint64(len(a)))
+	var opts []fft.Option
+	if coset {
+		opts = append(opts, fft.OnCoset())
+	}
+	if inverse {
+		domain.FFTInverse(a, fft.DIF, opts...)
+	} else {
+		domain.FFT(a, fft.DIF, opts...)
+	}
+	fft.BitReverse(a)
+}
+
+func TestProverWithBlindingFactors(t *testing.T) {
+	assert := require.New(t)
+
+	const nbConstraints = 10
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: nbConstraints})
+	assert.NoError(err)
+	srs, err := test.NewKZGSRS(ccs)
+	assert.NoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+
+	exp := new(big.Int).Lsh(big.NewInt(1), nbConstraints)
+	y := new(big.Int).Exp(big.NewInt(2), exp, ecc.BN254.ScalarField())
+	fullWitness, err := frontend.NewWitness(&refCircuit{X: 2, Y: y}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	for n := 0; n <= backend.MaxNbBlindingFactors; n++ {
+		proof, err := plonk.Prove(ccs, pk, fullWitness, backend.WithBlindingFactors(n))
+		assert.NoError(err)
+		assert.NoError(plonk.Verify(proof, vk, publicWitness), "n=%d", n)
+	}
+
+	_, err = plonk.Prove(ccs, pk, fullWitness, backend.WithBlindingFactors(backend.MaxNbBlindingFactors+1))
+	assert.ErrorIs(err, backend.ErrNbBlindingFactors)
+	_, err = plonk.Prove(ccs, pk, fullWitness, backend.WithBlindingFactors(-1))
+	assert.ErrorIs(err, backend.ErrNbBlindingFactors)
+}
+
+func TestProverWithoutZeroKnowledgeIsSound(t *testing.T) {
+	assert := require.New(t)
+
+	const nbConstraints = 10
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: nbConstraints})
+	assert.NoError(err)
+	pk, vk, err := plonk.SetupTest(ccs, 1)
+	assert.NoError(err)
+
+	exp := new(big.Int).Lsh(big.NewInt(1), nbConstraints)
+	y := new(big.Int).Exp(big.NewInt(2), exp, ecc.BN254.ScalarField())
+	fullWitness, err := frontend.NewWitness(&refCircuit{X: 2, Y: y}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	// the keys of a single setup are used for proofs with and without
+	// zero-knowledge
+	zkProof, err := plonk.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(zkProof, vk, publicWitness))
+	proof, err := plonk.Prove(ccs, pk, fullWitness, backend.WithBlindingFactors(0))
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(proof, vk, publicWitness))
+
+	// a wrong public input
+	wrongY := new(big.Int).Add(y, big.NewInt(1))
+	wrongPublic, err := frontend.NewWitness(&refCircuit{Y: wrongY}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	assert.NoError(err)
+	assert.Error(plonk.Verify(proof, vk, wrongPublic))
+
+	// a wrong secret input, which the solver rejects
+	wrongWitness, err := frontend.NewWitness(&refCircuit{X: 3, Y: y}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	_, err = plonk.Prove(ccs, pk, wrongWitness, backend.WithBlindingFactors(0))
+	assert.Error(err)
+
+	// a witness which doesn't satisfy the constraints, proven without the
+	// solver rejecting it: the solution of a circuit which only differs by a
+	// constant is used with the proving key of the circuit
+	offsetCCS, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &offsetCircuit{offset: 1})
+	assert.NoError(err)
+	otherCCS, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &offsetCircuit{offset: 2})
+	assert.NoError(err)
+	offsetPK, offsetVK, err := plonk.SetupTest(offsetCCS, 1)
+	assert.NoError(err)
+	otherPK, otherVK, err := plonk.SetupTest(otherCCS, 1)
+	assert.NoError(err)
+	offsetWitness, err := frontend.NewWitness(&offsetCircuit{X: 3, Y: 8}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	offsetPublic, err := offsetWitness.Public()
+	assert.NoError(err)
+	assert.Error(otherCCS.IsSolved(offsetWitness))
+	offsetProof, err := plonk.Prove(offsetCCS, offsetPK, offsetWitness, backend.WithBlindingFactors(0))
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(offsetProof, offsetVK, offsetPublic))
+	offsetProof, err = plonk.Prove(offsetCCS, otherPK, offsetWitness, backend.WithBlindingFactors(0))
+	assert.NoError(err)
+	assert.Error(plonk.Verify(offsetProof, otherVK, offsetPublic))
+
+	// any altered byte of the proof is rejected, either when decoding it or
+	// when verifying it. An altered format tag is read as a legacy proof
+	// followed by other data, which is rejected as well.
+	var buf bytes.Buffer
+	_, err = proof.WriteTo(&buf)
+	assert.NoError(err)
+	encoded := buf.Bytes()
+	for i := range encoded {
+		tampered := bytes.Clone(encoded)
+		tampered[i] ^= 1
+		p := plonk.NewProof(ecc.BN254)
+		if n, err := p.ReadFrom(bytes.NewReader(tampered)); err != nil || n != int64(len(tampered)) {
+			continue
+		}
+		assert.Error(plonk.Verify(p, vk, publicWitness), "byte %d", i)
+	}
+}
+
+// offsetCircuit asserts that X² = Y + offset.
+type offsetCircuit struct {
+	X      frontend.Variable
+	Y      frontend.Variable `gnark:",public"`
+	offset int
+}
+
+func (c *offsetCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), api.Add(c.Y, c.offset))
+	return nil
+}
+
+func TestProverWithQuotientDegree(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, _solution, srs := referenceCircuit(ecc.BN254)
+	fullWitness, err := frontend.NewWitness(_solution, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	pk, vk, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+
+	for _, factor := range []int{4, 8} {
+		proof, err := plonk.Prove(ccs, pk, fullWitness, backend.WithQuotientDegree(factor))
+		assert.NoError(err)
+		assert.NoError(plonk.Verify(proof, vk, publicWitness), "factor=%d", factor)
+	}
+
+	// the quotient polynomial does not fit in a domain twice as large
+	_, err = plonk.Prove(ccs, pk, fullWitness, backend.WithQuotientDegree(2))
+	assert.Error(err)
+	_, err = plonk.Prove(ccs, pk, fullWitness, backend.WithQuotientDegree(3))
+	assert.Error(err)
+
+	// the proving key is unchanged
+	proof, err := plonk.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(proof, vk, publicWitness))
+}
+
+// unregisteredHint is not registered in the solver.
+func unregisteredHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	outputs[0].Set(inputs[0])
+	return nil
+}
+
+type unregisteredHintCircuit struct {
+	X frontend.Variable `gnark:",public"`
+}
+
+func (c *unregisteredHintCircuit) Define(api frontend.API) error {
+	res, err := api.Compiler().NewHint(unregisteredHint, 1, c.X)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(res[0], c.X)
+	return nil
+}
+
+func TestProverMissingHint(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &unregisteredHintCircuit{})
+	assert.NoError(err)
+	assert.Equal([]solver.HintID{solver.GetHintID(unregisteredHint)}, ccs.Hints())
+
+	srs, err := test.NewKZGSRS(ccs)
+	assert.NoError(err)
+	pk, _, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&unregisteredHintCircuit{X: 3}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	_, err = plonk.Prove(ccs, pk, fullWitness)
+	assert.ErrorContains(err, solver.GetHintName(unregisteredHint))
+
+	_, err = plonk.Prove(ccs, pk, fullWitness, backend.WithSolverOptions(solver.WithHints(unregisteredHint)))
+	assert.NoError(err)
+}
+
+func TestProverWithFFTProvider(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, _solution, srs := referenceCircuit(ecc.BN254)
+	fullWitness, err := frontend.NewWitness(_solution, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+
+	pk, vk, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+
+	var provider fftProvider
+	proof, err := plonk.Prove(ccs, pk, fullWitness, backend.WithFFTProvider(&provider))
+	assert.NoError(err)
+	assert.NotZero(provider.nbCalls.Load(), "the FFT provider was not used")
+
+	err = plonk.Verify(proof, vk, publicWitness)
+	assert.NoError(err)
+}
+
+type twoPublicCircuit struct {
+	X, Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *twoPublicCircuit) Define(api frontend.API) error {
+	api.AssertIsDifferent(circuit.X, circuit.Y)
+	return nil
+}
+
+// twoPublicFixture is a proof of twoPublicCircuit on BN254, with keys from
+// plonk.SetupTest.
+type twoPublicFixture struct {
+	ccs                        constraint.ConstraintSystem
+	pk                         plonk.ProvingKey
+	vk                         plonk.VerifyingKey
+	fullWitness, publicWitness witness.Witness
+	proof                      plonk.Proof
+}
+
+func newTwoPublicFixture(t *testing.T) twoPublicFixture {
+	assert := require.New(t)
+
+	var f twoPublicFixture
+	var err error
+	f.ccs, err = frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &twoPublicCircuit{})
+	assert.NoError(err)
+	f.pk, f.vk, err = plonk.SetupTest(f.ccs, 42)
+	assert.NoError(err)
+	f.fullWitness, err = frontend.NewWitness(&twoPublicCircuit{X: 1, Y: 2}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	f.publicWitness, err = f.fullWitness.Public()
+	assert.NoError(err)
+	f.proof, err = plonk.Prove(f.ccs, f.pk, f.fullWitness)
+	assert.NoError(err)
+	return f
+}
+
+func TestBatchVerifier(t *testing.T) {
+	assert := require.New(t)
+
+	const nbConstraints = 10
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: nbConstraints})
+	assert.NoError(err)
+	srs, err := test.NewKZGSRS(ccs)
+	assert.NoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+
+	bv := plonk.NewBatchVerifier(vk)
+	assert.Error(bv.Verify(), "an empty batch should not verify")
+
+	var lastProof plonk.Proof
+	var lastPublicWitness witness.Witness
+	for x := int64(2); x < 5; x++ {
+		exp := new(big.Int).Lsh(big.NewInt(1), nbConstraints)
+		y := new(big.Int).Exp(big.NewInt(x), exp, ecc.BN254.ScalarField())
+		fullWitness, err := frontend.NewWitness(&refCircuit{X: x, Y: y}, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		publicWitness, err := fullWitness.Public()
+		assert.NoError(err)
+		proof, err := plonk.Prove(ccs, pk, fullWitness)
+		assert.NoError(err)
+
+		assert.NoError(bv.Add(proof, publicWitness))
+		lastProof, lastPublicWitness = proof, publicWitness
+	}
+	assert.NoError(bv.Verify())
+
+	// a proof for the wrong public input is rejected when added
+	wrongWitness, err := frontend.NewWitness(&refCircuit{Y: 1}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	assert.NoError(err)
+	assert.Error(bv.Add(lastProof, wrongWitness))
+	assert.NoError(bv.Verify(), "a rejected proof should not be accumulated")
+
+	// a wrong opening proof is only caught by the final pairing check
+	tampered := *lastProof.(*plonk_bn254.Proof)
+	tampered.ZShiftedOpening.H.Add(&tampered.ZShiftedOpening.H, &tampered.Z)
+	assert.NoError(bv.Add(&tampered, lastPublicWitness))
+	assert.Error(bv.Verify())
+}
+
+func TestBatchVerify(t *testing.T) {
+	assert := require.New(t)
+
+	const nbConstraints = 10
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: nbConstraints})
+	assert.NoError(err)
+	pk, vk, err := plonk.SetupTest(ccs, 1)
+	assert.NoError(err)
+
+	var proofs []plonk.Proof
+	var publicWitnesses []witness.Witness
+	for x := int64(2); x < 6; x++ {
+		exp := new(big.Int).Lsh(big.NewInt(1), nbConstraints)
+		y := new(big.Int).Exp(big.NewInt(x), exp, ecc.BN254.ScalarField())
+		fullWitness, err := frontend.NewWitness(&refCircuit{X: x, Y: y}, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		publicWitness, err := fullWitness.Public()
+		assert.NoError(err)
+		proof, err := plonk.Prove(ccs, pk, fullWitness)
+		assert.NoError(err)
+		proofs = append(proofs, proof)
+		publicWitnesses = append(publicWitnesses, publicWitness)
+	}
+	assert.NoError(plonk.BatchVerify(proofs, vk, publicWitnesses))
+
+	assert.Error(plonk.BatchVerify(nil, vk, nil), "an empty batch should not verify")
+	assert.Error(plonk.BatchVerify(proofs, vk, publicWitnesses[1:]))
+
+	// a proof for the wrong public input is rejected when added
+	swapped := append([]witness.Witness{}, publicWitnesses...)
+	swapped[1], swapped[2] = publicWitnesses[2], publicWitnesses[1]
+	err = plonk.BatchVerify(proofs, vk, swapped)
+	assert.ErrorContains(err, "proof 1:")
+
+	// a wrong opening proof is only caught by the final pairing check
+	tampered := *proofs[2].(*plonk_bn254.Proof)
+	tampered.ZShiftedOpening.H.Add(&tampered.ZShiftedOpening.H, &tampered.Z)
+	withTampered := append([]plonk.Proof{}, proofs...)
+	withTampered[2] = &tampered
+	err = plonk.BatchVerify(withTampered, vk, publicWitnesses)
+	assert.ErrorContains(err, "proof 2:")
+
+	// the first invalid proof is reported, even when a later one is rejected
+	// when added
+	tampered = *proofs[1].(*plonk_bn254.Proof)
+	tampered.ZShiftedOpening.H.Add(&tampered.ZShiftedOpening.H, &tampered.Z)
+	withTampered = append([]plonk.Proof{}, proofs...)
+	withTampered[1] = &tampered
+	wrongPublic := append([]witness.Witness{}, publicWitnesses...)
+	wrongPublic[3] = publicWitnesses[0]
+	err = plonk.BatchVerify(withTampered, vk, wrongPublic)
+	assert.ErrorContains(err, "proof 1:")
+
+	// proofs and witnesses must be on the curve of the verifying key
+	otherWitness, err := frontend.NewWitness(&refCircuit{Y: 1}, ecc.BLS12_381.ScalarField(), frontend.PublicOnly())
+	assert.NoError(err)
+	otherCurve := append([]witness.Witness{}, publicWitnesses...)
+	otherCurve[3] = otherWitness
+	err = plonk.BatchVerify(proofs, vk, otherCurve)
+	assert.ErrorIs(err, witness.ErrInvalidWitness)
+	assert.ErrorContains(err, "proof 3:")
+	err = plonk.BatchVerify(append(proofs[:3:3], plonk.NewProof(ecc.BLS12_381)), vk, publicWitnesses)
+	assert.ErrorIs(err, witness.ErrInvalidWitness)
+}
+
+type commitmentCircuit struct {
+	X [3]frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *commitmentCircuit) Define(api frontend.API) error {
+	commitment, err := api.(frontend.Committer).Commit(circuit.X[:]...)
+	if err != nil {
+		return err
+	}
+	api.AssertIsDifferent(commitment, api.Add(circuit.X[0], circuit.Y))
+	return nil
+}
+
+func TestSetupDeterministic(t *testing.T) {
+	circuits := map[string]frontend.Circuit{
+		"ref":        &refCircuit{nbConstraints: 100},
+		"public":     &twoPublicCircuit{},
+		"commitment": &commitmentCircuit{},
+	}
+	for _, curve := range getCurves() {
+		for name, circuit := range circuits {
+			t.Run(curve.String()+"/"+name, func(t *testing.T) {
+				assert := require.New(t)
+
+				// two setups of the same constraint system, and a setup of the
+				// constraint system compiled again.
+				var vks [3]bytes.Buffer
+				var ccs constraint.ConstraintSystem
+				var srs kzg.SRS
+				for i := range vks {
+					if i != 1 {
+						var err error
+						ccs, err = frontend.Compile(curve.ScalarField(), scs.NewBuilder, circuit)
+						assert.NoError(err)
+						if srs == nil {
+							srs, err = test.NewKZGSRS(ccs)
+							assert.NoError(err)
+						}
+					}
+					_, vk, err := plonk.Setup(ccs, srs)
+					assert.NoError(err)
+					_, err = vk.WriteTo(&vks[i])
+					assert.NoError(err)
+				}
+				assert.True(bytes.Equal(vks[0].Bytes(), vks[1].Bytes()), "setups of the same constraint system differ")
+				assert.True(bytes.Equal(vks[0].Bytes(), vks[2].Bytes()), "setups of two compilations of the circuit differ")
+			})
+		}
+	}
+}
+
+func TestVerifyDomainSize(t *testing.T) {
+	assert := require.New(t)
+
+	f := newTwoPublicFixture(t)
+
+	_proof := f.proof.(*plonk_bn254.Proof)
+	assert.Equal(f.vk.(*plonk_bn254.VerifyingKey).Size, _proof.DomainSize)
+	assert.NoError(plonk.Verify(f.proof, f.vk, f.publicWitness))
+
+	// proofs in the legacy format carry no domain size
+	_proof.DomainSize = 0
+	assert.NoError(plonk.Verify(f.proof, f.vk, f.publicWitness))
+
+	_proof.DomainSize = 2 * f.vk.(*plonk_bn254.VerifyingKey).Size
+	assert.Error(plonk.Verify(f.proof, f.vk, f.publicWitness))
+}
+
+func TestEncodeCalldata(t *testing.T) {
+	assert := require.New(t)
+
+	f := newTwoPublicFixture(t)
+
+	calldata, err := plonk.EncodeCalldata(f.proof, f.publicWitness)
+	assert.NoError(err)
+
+	_, err = plonk.EncodeCalldata(plonk.NewProof(ecc.BLS12_381), f.publicWitness)
+	assert.ErrorContains(err, ecc.BLS12_381.String())
+
+	word := func(i int) *big.Int {
+		return new(big.Int).SetBytes(calldata[4+32*i : 4+32*(i+1)])
+	}
+
+	// selector of Verify(bytes,uint256[])
+	assert.Equal([]byte{0x7e, 0x4f, 0x7a, 0x8a}, calldata[:4])
+
+	proofBytes := f.proof.(*plonk_bn254.Proof).MarshalSolidity()
+	assert.Equal(int64(64), word(0).Int64())
+	assert.Equal(int64(96+len(proofBytes)), word(1).Int64())
+	assert.Equal(int64(len(proofBytes)), word(2).Int64())
+	assert.Equal(proofBytes, calldata[4+96:4+96+len(proofBytes)])
+
+	nbProofWords := len(proofBytes) / 32
+	assert.Equal(int64(2), word(3+nbProofWords).Int64())
+	assert.Equal(int64(1), word(4+nbProofWords).Int64())
+	assert.Equal(int64(2), word(5+nbProofWords).Int64())
+	assert.Len(calldata, 4+32*(6+nbProofWords))
+}
+
+func TestRandomUnconstrainedWitness(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+	assert.NoError(err)
+	srs, err := test.NewKZGSRS(ccs)
+	assert.NoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+
+	for seed := int64(0); seed < 3; seed++ {
+		fullWitness, err := plonk.RandomUnconstrainedWitness(ccs, seed)
+		assert.NoError(err)
+		publicWitness, err := fullWitness.Public()
+		assert.NoError(err)
+		proof, err := plonk.Prove(ccs, pk, fullWitness)
+		assert.NoError(err)
+		assert.NoError(plonk.Verify(proof, vk, publicWitness))
+
+		// the witness only depends on the seed
+		again, err := plonk.RandomUnconstrainedWitness(ccs, seed)
+		assert.NoError(err)
+		assert.Equal(fullWitness.Vector(), again.Vector())
+	}
+
+	// random inputs can't satisfy an assertion relating them
+	ccs, err = frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: 2})
+	assert.NoError(err)
+	_, err = plonk.RandomUnconstrainedWitness(ccs, 0)
+	assert.ErrorIs(err, plonk.ErrConstrainedInputs)
+}
+
+func TestVerifyAny(t *testing.T) {
+	assert := require.New(t)
+
+	var vks []plonk.VerifyingKey
+	for _, circuit := range []frontend.Circuit{&refCircuit{nbConstraints: 100}, &commitmentCircuit{}} {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
+		assert.NoError(err)
+		srs, err := test.NewKZGSRS(ccs)
+		assert.NoError(err)
+		_, vk, err := plonk.Setup(ccs, srs)
+		assert.NoError(err)
+		vks = append(vks, vk)
+	}
+
+	f := newTwoPublicFixture(t)
+
+	i, err := plonk.VerifyAny(f.proof, append(vks, f.vk), f.publicWitness)
+	assert.NoError(err)
+	assert.Equal(2, i)
+
+	// proofs in the legacy format carry no domain size
+	f.proof.(*plonk_bn254.Proof).DomainSize = 0
+	i, err = plonk.VerifyAny(f.proof, append(vks, f.vk), f.publicWitness)
+	assert.NoError(err)
+	assert.Equal(2, i)
+
+	i, err = plonk.VerifyAny(f.proof, vks, f.publicWitness)
+	assert.Error(err)
+	assert.Equal(-1, i)
+}
+
+func TestCircuitMetrics(t *testing.T) {
+	for name, circuit := range map[string]frontend.Circuit{
+		"public":     &twoPublicCircuit{},
+		"commitment": &commitmentCircuit{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
+			assert.NoError(err)
+			srs, err := test.NewKZGSRS(ccs)
+			assert.NoError(err)
+			pk, vk, err := plonk.Setup(ccs, srs)
+			assert.NoError(err)
+			fullWitness, err := plonk.RandomUnconstrainedWitness(ccs, 0)
+			assert.NoError(err)
+			publicWitness, err := fullWitness.Public()
+			assert.NoError(err)
+			proof, err := plonk.Prove(ccs, pk, fullWitness)
+			assert.NoError(err)
+
+			m := plonk.CircuitMetrics(vk)
+			assert.Equal(ccs.GetNbPublicVariables(), m.NbPublicInputs)
+
+			var buf bytes.Buffer
+			_, err = proof.WriteTo(&buf)
+			assert.NoError(err)
+			assert.Equal(buf.Len(), m.ProofSize)
+
+			// the estimate accounts for the calldata of the call
+			calldata, err := plonk.EncodeCalldata(proof, publicWitness)
+			assert.NoError(err)
+			assert.Greater(m.SolidityGas, uint64(16*len(calldata)+113000))
+		})
+	}
+}
+
+func TestVerifyInstrumented(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &commitmentCircuit{})
+	assert.NoError(err)
+	srs, err := test.NewKZGSRS(ccs)
+	assert.NoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+	fullWitness, err := plonk.RandomUnconstrainedWitness(ccs, 0)
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+	proof, err := plonk.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+
+	stats, err := plonk.VerifyInstrumented(proof, vk, publicWitness)
+	assert.NoError(err)
+	assert.Greater(stats.Challenges, time.Duration(0))
+	assert.Greater(stats.MSM, time.Duration(0))
+	assert.Greater(stats.Pairing, time.Duration(0))
+
+	// a wrong public input is detected before the pairing
+	wrongWitness, err := plonk.RandomUnconstrainedWitness(ccs, 1)
+	assert.NoError(err)
+	wrongPublic, err := wrongWitness.Public()
+	assert.NoError(err)
+	stats, err = plonk.VerifyInstrumented(proof, vk, wrongPublic)
+	assert.Error(err)
+	assert.Greater(stats.Challenges, time.Duration(0))
+	assert.Equal(time.Duration(0), stats.Pairing)
+}
+
+// lookupCircuit looks up the secret index I in a table of constants, the
+// lookup being committed to with a BSB22 commitment.
+type lookupCircuit struct {
+	X frontend.Variable `gnark:",public"`
+	I frontend.Variable
+}
+
+func (c *lookupCircuit) Define(api frontend.API) error {
+	table := logderivlookup.New(api)
+	for i := 0; i < 8; i++ {
+		table.Insert(i * i)
+	}
+	res := table.Lookup(c.I)[0]
+	api.AssertIsLessOrEqual(res, c.X)
+	return nil
+}
+
+func TestProverBlindsLookupCommitments(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &lookupCircuit{})
+	assert.NoError(err)
+	srs, err := test.NewKZGSRS(ccs)
+	assert.NoError(err)
+	pk, vk, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+
+	commitments := func(i int, opts ...backend.ProverOption) []curve.G1Affine {
+		fullWitness, err := frontend.NewWitness(&lookupCircuit{X: 49, I: i}, ecc.BN254.ScalarField())
+		assert.NoError(err)
+		publicWitness, err := fullWitness.Public()
+		assert.NoError(err)
+		proof, err := plonk.Prove(ccs, pk, fullWitness, opts...)
+		assert.NoError(err)
+		assert.NoError(plonk.Verify(proof, vk, publicWitness))
+		return proof.(*plonk_bn254.Proof).Bsb22Commitments
+	}
+
+	// without blinding, the commitments are a function of the secret witness
+	unblinded := commitments(3, backend.WithBlindingFactors(0))
+	assert.NotEmpty(unblinded)
+	assert.Equal(unblinded, commitments(3, backend.WithBlindingFactors(0)))
+	assert.NotEqual(unblinded, commitments(5, backend.WithBlindingFactors(0)))
+
+	// with blinding, two proofs of the same or of different secret witnesses
+	// have fresh random commitments
+	blinded := [][]curve.G1Affine{commitments(3), commitments(3), commitments(5)}
+	for i := range blinded {
+		assert.NotEqual(unblinded, blinded[i])
+		for j := 0; j < i; j++ {
+			assert.NotEqual(blinded[j], blinded[i])
+		}
+	}
+}
+
+func TestMockProve(t *testing.T) {
+	assert := require.New(t)
+
+	f := newTwoPublicFixture(t)
+
+	proof, err := plonk.MockProve(f.ccs, f.pk, f.publicWitness)
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	_, err = proof.WriteTo(&buf)
+	assert.NoError(err)
+	assert.Equal(f.vk.(*plonk_bn254.VerifyingKey).ProofSize(), buf.Len(), "mock proofs should have the size of real ones")
+
+	decoded := plonk.NewProof(ecc.BN254)
+	_, err = decoded.ReadFrom(&buf)
+	assert.NoError(err)
+	assert.True(decoded.(*plonk_bn254.Proof).Mock)
+	err = plonk.Verify(decoded, f.vk, f.publicWitness)
+	assert.Error(err)
+	assert.Contains(err.Error(), "mock proof")
+}
+
+func TestProofsEqual(t *testing.T) {
+	assert := require.New(t)
+
+	f := newTwoPublicFixture(t)
+
+	// the same proof, read from its compressed and its raw encodings
+	var compressed, raw bytes.Buffer
+	_, err := f.proof.WriteTo(&compressed)
+	assert.NoError(err)
+	_, err = f.proof.WriteRawTo(&raw)
+	assert.NoError(err)
+	fromCompressed := plonk.NewProof(ecc.BN254)
+	_, err = fromCompressed.ReadFrom(&compressed)
+	assert.NoError(err)
+	fromRaw := plonk.NewProof(ecc.BN254)
+	_, err = fromRaw.ReadFrom(&raw)
+	assert.NoError(err)
+	assert.True(plonk.ProofsEqual(f.proof, fromCompressed))
+	assert.True(plonk.ProofsEqual(fromCompressed, fromRaw))
+
+	// proofs are blinded, so proving again yields a different proof
+	other, err := plonk.Prove(f.ccs, f.pk, f.fullWitness)
+	assert.NoError(err)
+	assert.False(plonk.ProofsEqual(f.proof, other))
+
+	assert.False(plonk.ProofsEqual(f.proof, plonk.NewProof(ecc.BLS12_381)))
+
+	// the same proof in the legacy format, without domain size
+	legacy := *f.proof.(*plonk_bn254.Proof)
+	legacy.DomainSize = 0
+	var legacyEncoded bytes.Buffer
+	_, err = legacy.WriteTo(&legacyEncoded)
+	assert.NoError(err)
+	fromLegacy := plonk.NewProof(ecc.BN254)
+	_, err = fromLegacy.ReadFrom(&legacyEncoded)
+	assert.NoError(err)
+	assert.Zero(fromLegacy.(*plonk_bn254.Proof).DomainSize)
+	assert.True(plonk.ProofsEqual(f.proof, fromLegacy))
+}
+
+func TestPrepareVerify(t *testing.T) {
+	assert := require.New(t)
+
+	f := newTwoPublicFixture(t)
+
+	inputs, err := plonk.PrepareVerify(f.proof, f.vk, f.publicWitness)
+	assert.NoError(err)
+	assert.Equal(ecc.BN254, inputs.Curve())
+	assert.NoError(plonk.FinishVerify(inputs))
+
+	// the external component gets the points of the pairing check
+	_inputs := inputs.(*plonk_bn254.PairingInputs)
+	assert.Len(_inputs.G1, len(_inputs.G2))
+	_inputs.G1[0], _inputs.G1[1] = _inputs.G1[1], _inputs.G1[0]
+	assert.Error(plonk.FinishVerify(inputs))
+
+	wrongWitness, err := frontend.NewWitness(&twoPublicCircuit{X: 2, Y: 2}, ecc.BN254.ScalarField(), frontend.PublicOnly())
+	assert.NoError(err)
+	_, err = plonk.PrepareVerify(f.proof, f.vk, wrongWitness)
+	assert.Error(err)
+}
+
+func TestProofStructure(t *testing.T) {
+	assert := require.New(t)
+
+	for _, curve := range getCurves() {
+		nbG1, nbG2, nbScalars := plonk.ProofStructure(curve)
+		assert.Equal(9, nbG1, curve.String())
+		assert.Equal(0, nbG2, curve.String())
+		assert.Equal(8, nbScalars, curve.String())
+	}
+
+	// the claimed values of a proof are the scalars
+	f := newTwoPublicFixture(t)
+	_, _, nbScalars := plonk.ProofStructure(ecc.BN254)
+	_proof := f.proof.(*plonk_bn254.Proof)
+	assert.Equal(nbScalars, len(_proof.BatchedProof.ClaimedValues)+1)
+}
+
+func TestSetupTest(t *testing.T) {
+	assert := require.New(t)
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &twoPublicCircuit{})
+	assert.NoError(err)
+
+	vkBytes := func(seed int64) []byte {
+		_, vk, err := plonk.SetupTest(ccs, seed)
+		assert.NoError(err)
+		var buf bytes.Buffer
+		_, err = vk.WriteTo(&buf)
+		assert.NoError(err)
+		return buf.Bytes()
+	}
+	assert.Equal(vkBytes(42), vkBytes(42), "the setup should be reproducible")
+	assert.NotEqual(vkBytes(42), vkBytes(43))
+
+	pk, vk, err := plonk.SetupTest(ccs, 42)
+	assert.NoError(err)
+	fullWitness, err := frontend.NewWitness(&twoPublicCircuit{X: 1, Y: 2}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+	proof, err := plonk.Prove(ccs, pk, fullWitness)
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(proof, vk, publicWitness))
+}
+
+func TestProofBinding(t *testing.T) {
+	assert := require.New(t)
+
+	f := newTwoPublicFixture(t)
+
+	tag := []byte("chain 1, contract 0x42")
+	proof, err := plonk.Prove(f.ccs, f.pk, f.fullWitness, backend.WithProofBinding(tag))
+	assert.NoError(err)
+	assert.NoError(plonk.VerifyWithBinding(proof, f.vk, f.publicWitness, tag))
+	assert.Error(plonk.VerifyWithBinding(proof, f.vk, f.publicWitness, []byte("chain 2, contract 0x42")), "the proof should be bound to its tag")
+	assert.Error(plonk.Verify(proof, f.vk, f.publicWitness), "a bound proof shouldn't verify without its tag")
+
+	// without binding, the proofs are the usual ones
+	proof, err = plonk.Prove(f.ccs, f.pk, f.fullWitness)
+	assert.NoError(err)
+	assert.NoError(plonk.VerifyWithBinding(proof, f.vk, f.publicWitness, nil))
+	assert.Error(plonk.VerifyWithBinding(proof, f.vk, f.publicWitness, tag))
+}
+
+type squareCircuit struct {
+	X, Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *squareCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(circuit.X, circuit.X), circuit.Y)
+	return nil
+}
+
+type incrementCircuit struct {
+	Y, Z frontend.Variable `gnark:",public"`
+}
+
+func (circuit *incrementCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Add(circuit.Y, 1), circuit.Z)
+	return nil
+}
+
+func TestVerifyLinked(t *testing.T) {
+	assert := require.New(t)
+
+	// Z = X² + 1, split in two circuits linked by Y = X²
+	circuits := []frontend.Circuit{&squareCircuit{}, &incrementCircuit{}}
+	assignments := []frontend.Circuit{&squareCircuit{X: 3, Y: 9}, &incrementCircuit{Y: 9, Z: 10}}
+	var proofs []plonk.Proof
+	var vks []plonk.VerifyingKey
+	var publics []witness.Witness
+	for i := range circuits {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuits[i])
+		assert.NoError(err)
+		pk, vk, err := plonk.SetupTest(ccs, 42)
+		assert.NoError(err)
+		fullWitness, err := frontend.NewWitness(assignments[i], ecc.BN254.ScalarField())
+		assert.NoError(err)
+		publicWitness, err := fullWitness.Public()
+		assert.NoError(err)
+		proof, err := plonk.Prove(ccs, pk, fullWitness)
+		assert.NoError(err)
+		proofs = append(proofs, proof)
+		vks = append(vks, vk)
+		publics = append(publics, publicWitness)
+	}
+
+	link := plonk.LinkConstraint{FromProof: 0, FromInput: 1, ToProof: 1, ToInput: 0}
+	assert.NoError(plonk.VerifyLinked(proofs, vks, publics, []plonk.LinkConstraint{link}))
+
+	// X isn't linked to Y
+	assert.Error(plonk.VerifyLinked(proofs, vks, publics, []plonk.LinkConstraint{{FromProof: 0, FromInput: 0, ToProof: 1, ToInput: 0}}))
+	// out of range
+	assert.Error(plonk.VerifyLinked(proofs, vks, publics, []plonk.LinkConstraint{{FromProof: 0, FromInput: 2, ToProof: 1, ToInput: 0}}))
+	assert.Error(plonk.VerifyLinked(proofs, vks, publics, []plonk.LinkConstraint{{FromProof: 0, FromInput: 1, ToProof: 2, ToInput: 0}}))
+	assert.Error(plonk.VerifyLinked(proofs, vks, publics, []plonk.LinkConstraint{{FromProof: 0, FromInput: -1, ToProof: 1, ToInput: 0}}))
+	assert.Error(plonk.VerifyLinked(proofs, vks, publics, []plonk.LinkConstraint{{FromProof: 0, FromInput: 1, ToProof: 1, ToInput: -1}}))
+	assert.Error(plonk.VerifyLinked(proofs, vks, publics, []plonk.LinkConstraint{{FromProof: -1, FromInput: 1, ToProof: 1, ToInput: 0}}))
+	assert.Error(plonk.VerifyLinked(proofs, vks[:1], publics, []plonk.LinkConstraint{link}))
+
+	// the proofs are verified too
+	assert.Error(plonk.VerifyLinked([]plonk.Proof{proofs[1], proofs[0]}, vks, publics, []plonk.LinkConstraint{link}))
+}
+
+func TestVerifyAgainstFingerprint(t *testing.T) {
+	assert := require.New(t)
+
+	f := newTwoPublicFixture(t)
+	_, otherVk, err := plonk.SetupTest(f.ccs, 43)
+	assert.NoError(err)
+
+	fingerprint := f.vk.Fingerprint()
+	assert.Len(fingerprint, 32)
+	assert.NotEqual(fingerprint, otherVk.Fingerprint())
+
+	fetch := func(vk plonk.VerifyingKey) func([]byte) (plonk.VerifyingKey, error) {
+		return func([]byte) (plonk.VerifyingKey, error) { return vk, nil }
+	}
+	assert.NoError(plonk.VerifyAgainstFingerprint(f.proof, fingerprint, f.publicWitness, fetch(f.vk)))
+
+	// the key is swapped
+	assert.Error(plonk.VerifyAgainstFingerprint(f.proof, fingerprint, f.publicWitness, fetch(otherVk)))
+	// the fingerprint matches, the proof doesn't
+	assert.Error(plonk.VerifyAgainstFingerprint(f.proof, otherVk.Fingerprint(), f.publicWitness, fetch(otherVk)))
+	// the key can't be fetched
+	assert.Error(plonk.VerifyAgainstFingerprint(f.proof, fingerprint, f.publicWitness, func([]byte) (plonk.VerifyingKey, error) {
+		return nil, errors.New("not found")
+	}))
+}
+
 func BenchmarkSetup(b *testing.B) {
 	for _, curve := range getCurves() {
 		b.Run(curve.String(), func(b *testing.B) {
@@ -167,3 +1089,108 @@ func getCurves() []ecc.ID {
 	}
 	return gnark.Curves()
 }
+
+type overDeterminedCircuit struct {
+	X, Y, Z frontend.Variable `gnark:",public"`
+	S       frontend.Variable
+}
+
+func (circuit *overDeterminedCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(circuit.Z, api.Add(api.Mul(circuit.X, circuit.Y), 3))
+	api.AssertIsEqual(api.Mul(circuit.S, circuit.S), circuit.X)
+	return nil
+}
+
+func TestQuickInfeasibilityCheck(t *testing.T) {
+	assert := require.New(t)
+
+	check := func(circuit, assignment frontend.Circuit) (bool, error) {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, circuit)
+		assert.NoError(err)
+		publicWitness, err := frontend.NewWitness(assignment, ecc.BN254.ScalarField(), frontend.PublicOnly())
+		assert.NoError(err)
+		return plonk.QuickInfeasibilityCheck(ccs, publicWitness)
+	}
+
+	infeasible, err := check(&overDeterminedCircuit{}, &overDeterminedCircuit{X: 4, Y: 3, Z: 15})
+	assert.NoError(err)
+	assert.False(infeasible)
+
+	infeasible, err = check(&overDeterminedCircuit{}, &overDeterminedCircuit{X: 4, Y: 3, Z: 16})
+	assert.NoError(err)
+	assert.True(infeasible)
+
+	// the contradiction involves the secret input: it is not detected
+	infeasible, err = check(&overDeterminedCircuit{}, &overDeterminedCircuit{X: 5, Y: 3, Z: 18})
+	assert.NoError(err)
+	assert.False(infeasible)
+
+	infeasible, err = check(&twoPublicCircuit{}, &twoPublicCircuit{X: 1, Y: 2})
+	assert.NoError(err)
+	assert.False(infeasible)
+
+	infeasible, err = check(&twoPublicCircuit{}, &twoPublicCircuit{X: 2, Y: 2})
+	assert.NoError(err)
+	assert.True(infeasible)
+
+	// public witness of another circuit
+	_, err = check(&twoPublicCircuit{}, &overDeterminedCircuit{X: 4, Y: 3, Z: 15})
+	assert.Error(err)
+}
+
+func TestUpdateVK(t *testing.T) {
+	assert := require.New(t)
+
+	compile := func(nbConstraints int) constraint.ConstraintSystem {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: nbConstraints})
+		assert.NoError(err)
+		return ccs
+	}
+	encode := func(vk plonk.VerifyingKey) []byte {
+		var buf bytes.Buffer
+		_, err := vk.WriteTo(&buf)
+		assert.NoError(err)
+		return buf.Bytes()
+	}
+
+	ccs, updated := compile(8), compile(12)
+	srs, err := test.NewKZGSRS(updated)
+	assert.NoError(err)
+	_, vk, err := plonk.Setup(ccs, srs)
+	assert.NoError(err)
+	pk, expected, err := plonk.Setup(updated, srs)
+	assert.NoError(err)
+	assert.Equal(vk.(*plonk_bn254.VerifyingKey).Size, expected.(*plonk_bn254.VerifyingKey).Size, "the circuits must have the same domain")
+
+	// the updated verifying key is the one of the setup of the updated circuit
+	vkUpdated, err := plonk.UpdateVK(vk, updated, srs)
+	assert.NoError(err)
+	assert.Equal(encode(expected), encode(vkUpdated))
+	assert.NotEqual(encode(vk), encode(vkUpdated))
+
+	exp := new(big.Int).Lsh(big.NewInt(1), 12)
+	y := new(big.Int).Exp(big.NewInt(2), exp, ecc.BN254.ScalarField())
+	fullWitness, err := frontend.NewWitness(&refCircuit{X: 2, Y: y}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicWitness, err := fullWitness.Public()
+	assert.NoError(err)
+	proof, err := plonk.Prove(updated, pk, fullWitness)
+	assert.NoError(err)
+	assert.NoError(plonk.Verify(proof, vkUpdated, publicWitness))
+
+	// different domain
+	_, err = plonk.UpdateVK(vk, compile(40), srs)
+	assert.Error(err)
+
+	// different srs
+	otherSrs, err := kzg_bn254.NewSRS(ecc.NextPowerOfTwo(uint64(updated.GetNbConstraints()))+3, big.NewInt(42))
+	assert.NoError(err)
+	_, err = plonk.UpdateVK(vk, updated, otherSrs)
+	assert.Error(err)
+
+	// different curve
+	other, err := frontend.Compile(ecc.BLS12_381.ScalarField(), scs.NewBuilder, &refCircuit{nbConstraints: 12})
+	assert.NoError(err)
+	_, err = plonk.UpdateVK(vk, other, srs)
+	assert.Error(err)
+}
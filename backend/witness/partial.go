@@ -0,0 +1,179 @@
+package witness
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// notAssigned is the type of NotAssigned.
+type notAssigned struct{}
+
+// NotAssigned is sent in place of a value to Witness.Fill to leave the value
+// unassigned, in a partial witness. The unassigned values are zero, and are
+// listed by Unassigned.
+//
+// A partial witness can only be serialized with PartialWriteTo, and must be
+// completed, for example with Merge, before being solved.
+var NotAssigned any = notAssigned{}
+
+// ErrPartialWitness is returned when a partially assigned witness is written
+// with Witness.WriteTo, or solved. The solver computes the wires constraint by
+// constraint, in an order fixed at compile time in which all the inputs are
+// known: it can't deduce the unassigned values, which must be provided first,
+// for example with Merge.
+var ErrPartialWitness = errors.New("witness is partially assigned")
+
+// markUnassigned marks the i-th of the n values of w as unassigned.
+func (w *witness) markUnassigned(i, n int) {
+	if w.assigned == nil {
+		w.assigned = make([]bool, n)
+		for j := range w.assigned {
+			w.assigned[j] = true
+		}
+	}
+	w.assigned[i] = false
+}
+
+// Unassigned returns the indices of the values of w which are not assigned,
+// ordered as described in the package documentation. It returns nil when w is
+// fully assigned.
+func Unassigned(w Witness) []int {
+	tw, ok := w.(*witness)
+	if !ok {
+		return nil
+	}
+	var res []int
+	for i, assigned := range tw.assigned {
+		if !assigned {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// PartialWriteTo writes the binary encoding of the partial witness w to wr. The
+// encoding is the one of Witness.WriteTo, where a bitmap of the assigned values
+// follows the number of public and secret values:
+//
+//	[uint32(nbPublic) | uint32(nbSecret) | bitmap | fr.Vector(variables)]
+//
+// Bit i%8 of byte i/8 of the bitmap is set when the i-th value is assigned.
+func PartialWriteTo(w Witness, wr io.Writer) (int64, error) {
+	tw, ok := w.(*witness)
+	if !ok {
+		return 0, fmt.Errorf("%w: unsupported witness type %T", ErrInvalidWitness, w)
+	}
+	nbValues := int(tw.nbPublic + tw.nbSecret)
+	bitmap := make([]byte, (nbValues+7)/8)
+	for i := 0; i < nbValues; i++ {
+		if tw.assigned == nil || tw.assigned[i] {
+			bitmap[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], tw.nbPublic)
+	binary.BigEndian.PutUint32(header[4:], tw.nbSecret)
+	n, err := wr.Write(header[:])
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := wr.Write(bitmap)
+	if err != nil {
+		return int64(n + m), err
+	}
+
+	// the vector is written as in a fully assigned witness, after the header.
+	var buf bytes.Buffer
+	full := witness{vector: tw.vector, nbPublic: tw.nbPublic, nbSecret: tw.nbSecret}
+	if _, err := full.WriteTo(&buf); err != nil {
+		return int64(n + m), err
+	}
+	k, err := wr.Write(buf.Bytes()[len(header):])
+	return int64(n + m + k), err
+}
+
+// PartialReadFrom reads into w the partial witness written by PartialWriteTo.
+// w must have been created with New, for the scalar field of the encoded
+// witness.
+func PartialReadFrom(w Witness, r io.Reader) (int64, error) {
+	tw, ok := w.(*witness)
+	if !ok {
+		return 0, fmt.Errorf("%w: unsupported witness type %T", ErrInvalidWitness, w)
+	}
+	var header [8]byte
+	n, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return int64(n), err
+	}
+	nbValues := int(binary.BigEndian.Uint32(header[:4])) + int(binary.BigEndian.Uint32(header[4:]))
+	bitmap := make([]byte, (nbValues+7)/8)
+	m, err := io.ReadFull(r, bitmap)
+	if err != nil {
+		return int64(n + m), err
+	}
+
+	// the vector is read as in a fully assigned witness, after the header.
+	k, err := tw.ReadFrom(io.MultiReader(bytes.NewReader(header[:]), r))
+	read := int64(m) + k
+	if err != nil {
+		return read, err
+	}
+	if l := reflect.ValueOf(tw.vector).Len(); l != nbValues {
+		return read, fmt.Errorf("%w: vector holds %d values, expected %d", ErrInvalidWitness, l, nbValues)
+	}
+
+	for i := 0; i < nbValues; i++ {
+		if bitmap[i/8]&(1<<(i%8)) == 0 {
+			tw.markUnassigned(i, nbValues)
+		}
+	}
+	return read, nil
+}
+
+// Merge assigns the values of w which are unassigned and which are assigned in
+// other. w and other must be witnesses of the same circuit. It returns an error
+// if a value is assigned in both witnesses with different values.
+//
+// Merge is the way to complete a partial witness before proving: the
+// constraint systems don't solve partially assigned witnesses.
+func Merge(w, other Witness) error {
+	tw, ok := w.(*witness)
+	if !ok {
+		return fmt.Errorf("%w: unsupported witness type %T", ErrInvalidWitness, w)
+	}
+	to, ok := other.(*witness)
+	if !ok {
+		return fmt.Errorf("%w: unsupported witness type %T", ErrInvalidWitness, other)
+	}
+	if reflect.TypeOf(tw.vector) != reflect.TypeOf(to.vector) || tw.nbPublic != to.nbPublic || tw.nbSecret != to.nbSecret {
+		return fmt.Errorf("%w: witnesses of different circuits", ErrInvalidWitness)
+	}
+
+	isAssigned := func(w *witness, i int) bool {
+		return w.assigned == nil || w.assigned[i]
+	}
+	dst, src := reflect.ValueOf(tw.vector), reflect.ValueOf(to.vector)
+	for i := 0; i < dst.Len(); i++ {
+		if !isAssigned(to, i) {
+			continue
+		}
+		if isAssigned(tw, i) {
+			if !reflect.DeepEqual(dst.Index(i).Interface(), src.Index(i).Interface()) {
+				return fmt.Errorf("%w: value %d is assigned in both witnesses with different values", ErrInvalidWitness, i)
+			}
+			continue
+		}
+		dst.Index(i).Set(src.Index(i))
+		tw.assigned[i] = true
+	}
+
+	if len(Unassigned(tw)) == 0 {
+		tw.assigned = nil
+	}
+	return nil
+}
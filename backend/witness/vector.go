@@ -247,3 +247,46 @@ func resize(v any, n int) any {
 		panic("invalid input")
 	}
 }
+
+// firstUnreduced returns the index of the first element of v whose internal
+// representation is not strictly smaller than the field modulus, or -1 if all
+// elements are reduced.
+func firstUnreduced(v any) int {
+	var modulus *big.Int
+	switch v.(type) {
+	case fr_bn254.Vector:
+		modulus = fr_bn254.Modulus()
+	case fr_bls12377.Vector:
+		modulus = fr_bls12377.Modulus()
+	case fr_bls12381.Vector:
+		modulus = fr_bls12381.Modulus()
+	case fr_bw6761.Vector:
+		modulus = fr_bw6761.Modulus()
+	case fr_bls24317.Vector:
+		modulus = fr_bls24317.Modulus()
+	case fr_bls24315.Vector:
+		modulus = fr_bls24315.Modulus()
+	case fr_bw6633.Vector:
+		modulus = fr_bw6633.Modulus()
+	case tinyfield.Vector:
+		modulus = tinyfield.Modulus()
+	default:
+		panic("invalid input")
+	}
+
+	// all element types are little-endian arrays of uint64 limbs.
+	rv := reflect.ValueOf(v)
+	var limbs, word big.Int
+	for i := 0; i < rv.Len(); i++ {
+		e := rv.Index(i)
+		limbs.SetUint64(0)
+		for j := e.Len() - 1; j >= 0; j-- {
+			limbs.Lsh(&limbs, 64)
+			limbs.Or(&limbs, word.SetUint64(e.Index(j).Uint()))
+		}
+		if limbs.Cmp(modulus) >= 0 {
+			return i
+		}
+	}
+	return -1
+}
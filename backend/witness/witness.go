@@ -47,6 +47,7 @@ import (
 	"math/big"
 	"reflect"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
@@ -98,6 +99,10 @@ type Witness interface {
 type witness struct {
 	vector             any
 	nbPublic, nbSecret uint32
+
+	// assigned marks the values of a partial witness which are assigned. It
+	// is nil when all the values are assigned.
+	assigned []bool
 }
 
 // New initialize a new empty Witness.
@@ -112,11 +117,41 @@ func New(field *big.Int) (Witness, error) {
 	}, nil
 }
 
+// FromVector wraps the fr.Vector v of the scalar field of curveID into a
+// Witness, without copying it. The first nbPublic elements of v are the public
+// values and the remaining ones the secret values, ordered as described in the
+// package documentation.
+//
+// v is shared with the returned Witness: modifying one modifies the other.
+func FromVector(curveID ecc.ID, v any, nbPublic int) (Witness, error) {
+	if curveID == ecc.UNKNOWN {
+		return nil, errors.New("unknown curve id")
+	}
+	expected, err := newVector(curveID.ScalarField(), 0)
+	if err != nil {
+		return nil, err
+	}
+	if reflect.TypeOf(v) != reflect.TypeOf(expected) {
+		return nil, fmt.Errorf("%w: expected a %T for %s, got %T", ErrInvalidWitness, expected, curveID, v)
+	}
+	n := reflect.ValueOf(v).Len()
+	if nbPublic < 0 || nbPublic > n {
+		return nil, fmt.Errorf("%w: %d public values in a vector of %d elements", ErrInvalidWitness, nbPublic, n)
+	}
+
+	return &witness{
+		vector:   v,
+		nbPublic: uint32(nbPublic),
+		nbSecret: uint32(n - nbPublic),
+	}, nil
+}
+
 func (w *witness) Fill(nbPublic, nbSecret int, values <-chan any) error {
 	n := nbPublic + nbSecret
 	w.vector = resize(w.vector, n)
 	w.nbPublic = uint32(nbPublic)
 	w.nbSecret = uint32(nbSecret)
+	w.assigned = nil
 
 	i := 0
 
@@ -131,6 +166,11 @@ func (w *witness) Fill(nbPublic, nbSecret int, values <-chan any) error {
 		// 	this is caught in the set method. however, error message will be unclear; reason
 		// is there is a nil field in assignment, we could print which one.
 		// }
+		if v == NotAssigned {
+			w.markUnassigned(i, n)
+			i++
+			continue
+		}
 		if err := set(w.vector, i, v); err != nil {
 			return err
 		}
@@ -153,13 +193,22 @@ func (w *witness) Public() (Witness, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &witness{
+	res := &witness{
 		vector:   v,
 		nbPublic: w.nbPublic,
-	}, nil
+	}
+	if w.assigned != nil {
+		res.assigned = append([]bool(nil), w.assigned[:w.nbPublic]...)
+	}
+	return res, nil
 }
 
+// WriteTo writes the binary encoding of w. It returns an error if w is only
+// partially assigned, see PartialWriteTo.
 func (w *witness) WriteTo(wr io.Writer) (n int64, err error) {
+	if len(Unassigned(w)) != 0 {
+		return 0, ErrPartialWitness
+	}
 	// write number of public, number of secret
 	if err := binary.Write(wr, binary.BigEndian, w.nbPublic); err != nil {
 		return 0, err
@@ -206,6 +255,7 @@ func (w *witness) ReadFrom(r io.Reader) (n int64, err error) {
 		return int64(read) + 4, err
 	}
 	w.nbSecret = binary.BigEndian.Uint32(buf[:4])
+	w.assigned = nil
 
 	n = 8
 
@@ -307,6 +357,36 @@ func (w *witness) ToJSON(s *schema.Schema) ([]byte, error) {
 // FromJSON parses a JSON data input and attempt to reconstruct a witness following the provided Schema.
 // This is a convenience method and should be avoided in most cases.
 func (w *witness) FromJSON(s *schema.Schema, data []byte) error {
+	return w.fromJSON(s, data, false)
+}
+
+// NewFromJSONAssignment returns the full witness of a circuit on curveID
+// whose schema is s, from a JSON object assigning each of its fields, as in
+//
+//	{"X": "0x2a", "Y": ["0x01", "12"]}
+//
+// The values are big integers, either decimal or hexadecimal with a 0x
+// prefix, as JSON numbers or strings. Unlike FromJSON, which returns a public
+// witness when secret values are missing, all the fields of the schema must
+// be assigned. An error naming the field is returned if one is missing or
+// unknown.
+func NewFromJSONAssignment(curveID ecc.ID, s *schema.Schema, data []byte) (Witness, error) {
+	if curveID == ecc.UNKNOWN {
+		return nil, errors.New("unknown curve id")
+	}
+	w, err := New(curveID.ScalarField())
+	if err != nil {
+		return nil, err
+	}
+	if err := w.(*witness).fromJSON(s, data, true); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// fromJSON implements FromJSON. When requireSecret is set, missing secret
+// values are an error instead of resulting in a public witness.
+func (w *witness) fromJSON(s *schema.Schema, data []byte, requireSecret bool) error {
 	typ := leafType(w.vector)
 	ptrTyp := reflect.PtrTo(typ)
 
@@ -353,6 +433,9 @@ func (w *witness) FromJSON(s *schema.Schema, data []byte) error {
 		}
 		return nil
 	}); err != nil {
+		if requireSecret {
+			return err
+		}
 		// missing secret values, we just do the public part.
 		publicOnly = true
 	}
@@ -386,3 +469,32 @@ func (w *witness) FromJSON(s *schema.Schema, data []byte) error {
 
 	return w.Fill(s.NbPublic, s.NbSecret, chValues)
 }
+
+// VerifyingKey is the subset of a proof system verifying key needed to check
+// that a public witness is well formed.
+type VerifyingKey interface {
+	NbPublicWitness() int // number of elements expected in the public witness
+}
+
+// Validate checks that w is a public witness matching vk: it must hold exactly
+// vk.NbPublicWitness() public values, no secret values, and each value must be
+// a reduced field element.
+func Validate(w Witness, vk VerifyingKey) error {
+	tw, ok := w.(*witness)
+	if !ok {
+		return fmt.Errorf("%w: unsupported witness type %T", ErrInvalidWitness, w)
+	}
+	if tw.nbSecret != 0 {
+		return fmt.Errorf("%w: expected a public witness, got %d secret values", ErrInvalidWitness, tw.nbSecret)
+	}
+	if int(tw.nbPublic) != vk.NbPublicWitness() {
+		return fmt.Errorf("%w: expected %d public values, got %d", ErrInvalidWitness, vk.NbPublicWitness(), tw.nbPublic)
+	}
+	if n := reflect.ValueOf(tw.vector).Len(); n != int(tw.nbPublic) {
+		return fmt.Errorf("%w: vector holds %d values, expected %d", ErrInvalidWitness, n, tw.nbPublic)
+	}
+	if i := firstUnreduced(tw.vector); i != -1 {
+		return fmt.Errorf("%w: public value %d is not a reduced field element", ErrInvalidWitness, i)
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/io"
 	"github.com/stretchr/testify/require"
 )
@@ -181,3 +182,151 @@ func TestVariableInitHook(t *testing.T) {
 	assert.True(ok)
 	assert.Len(fw, 10, "invalid length")
 }
+
+type nbPublicWitness int
+
+func (n nbPublicWitness) NbPublicWitness() int { return int(n) }
+
+func TestValidate(t *testing.T) {
+	assert := require.New(t)
+
+	var assignment circuit
+	assignment.X = new(fr.Element).SetInt64(42)
+	assignment.Y = new(fr.Element).SetInt64(8000)
+	assignment.E = new(fr.Element).SetInt64(1)
+
+	w, err := frontend.NewWitness(&assignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	publicW, err := w.Public()
+	assert.NoError(err)
+
+	assert.NoError(witness.Validate(publicW, nbPublicWitness(2)))
+	assert.ErrorIs(witness.Validate(publicW, nbPublicWitness(3)), witness.ErrInvalidWitness)
+	assert.ErrorIs(witness.Validate(w, nbPublicWitness(2)), witness.ErrInvalidWitness, "full witness")
+
+	// an element whose internal representation equals the modulus is not reduced.
+	var unreduced fr.Element
+	for i, l := range fr.Modulus().Bits() {
+		unreduced[i] = uint64(l)
+	}
+	publicW.Vector().(fr.Vector)[1] = unreduced
+	assert.ErrorIs(witness.Validate(publicW, nbPublicWitness(2)), witness.ErrInvalidWitness)
+}
+
+func TestFromVector(t *testing.T) {
+	assert := require.New(t)
+
+	v := make(fr.Vector, 3)
+	v[0].SetUint64(42)
+	v[1].SetUint64(8000)
+	v[2].SetUint64(1)
+
+	w, err := witness.FromVector(ecc.BN254, v, 2)
+	assert.NoError(err)
+
+	// the vector is wrapped, not copied
+	fw := w.Vector().(fr.Vector)
+	assert.Same(&v[0], &fw[0])
+
+	// matches the witness built from the assignment
+	expected, err := frontend.NewWitness(&circuit{X: 42, Y: 8000, E: 1}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	b, err := w.MarshalBinary()
+	assert.NoError(err)
+	bExpected, err := expected.MarshalBinary()
+	assert.NoError(err)
+	assert.Equal(bExpected, b)
+
+	_, err = witness.FromVector(ecc.BLS12_381, v, 2)
+	assert.ErrorIs(err, witness.ErrInvalidWitness, "vector of another curve")
+	_, err = witness.FromVector(ecc.BN254, []fr.Element(v), 2)
+	assert.ErrorIs(err, witness.ErrInvalidWitness, "not a fr.Vector")
+	_, err = witness.FromVector(ecc.BN254, v, 4)
+	assert.ErrorIs(err, witness.ErrInvalidWitness, "too many public values")
+}
+
+func TestPartialWitness(t *testing.T) {
+	assert := require.New(t)
+
+	// the first party assigns the public values, the second one the secret
+	// value.
+	first, err := frontend.NewWitness(&circuit{X: 42, Y: 8000}, ecc.BN254.ScalarField(), frontend.PartialAssignment())
+	assert.NoError(err)
+	assert.Equal([]int{2}, witness.Unassigned(first))
+	_, err = first.WriteTo(&bytes.Buffer{})
+	assert.ErrorIs(err, witness.ErrPartialWitness, "partial witnesses are written with PartialWriteTo")
+
+	var buf bytes.Buffer
+	written, err := witness.PartialWriteTo(first, &buf)
+	assert.NoError(err)
+	assert.Equal(int64(buf.Len()), written)
+
+	received, err := witness.New(ecc.BN254.ScalarField())
+	assert.NoError(err)
+	read, err := witness.PartialReadFrom(received, &buf)
+	assert.NoError(err)
+	assert.Equal(written, read)
+	assert.Equal([]int{2}, witness.Unassigned(received))
+
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit{})
+	assert.NoError(err)
+	_, err = ccs.Solve(received)
+	assert.ErrorIs(err, witness.ErrPartialWitness, "the solver needs a complete witness")
+
+	second, err := frontend.NewWitness(&circuit{E: 1}, ecc.BN254.ScalarField(), frontend.PartialAssignment())
+	assert.NoError(err)
+	assert.Equal([]int{0, 1}, witness.Unassigned(second))
+	assert.NoError(witness.Merge(received, second))
+	assert.Empty(witness.Unassigned(received))
+	_, err = ccs.Solve(received)
+	assert.NoError(err)
+
+	expected, err := frontend.NewWitness(&circuit{X: 42, Y: 8000, E: 1}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	assert.Equal(expected.Vector(), received.Vector())
+
+	// conflicting assignments are rejected
+	conflicting, err := frontend.NewWitness(&circuit{X: 43}, ecc.BN254.ScalarField(), frontend.PartialAssignment())
+	assert.NoError(err)
+	assert.ErrorIs(witness.Merge(received, conflicting), witness.ErrInvalidWitness)
+}
+
+type jsonAssignmentCircuit struct {
+	X frontend.Variable    `gnark:",public"`
+	Y [2]frontend.Variable `gnark:",public"`
+	E frontend.Variable
+}
+
+func (c *jsonAssignmentCircuit) Define(frontend.API) error {
+	return nil
+}
+
+func TestNewFromJSONAssignment(t *testing.T) {
+	assert := require.New(t)
+
+	s, err := frontend.NewSchema(&jsonAssignmentCircuit{})
+	assert.NoError(err)
+
+	w, err := witness.NewFromJSONAssignment(ecc.BN254, s, []byte(`{"X": "0x2a", "Y": ["0x1F40", "12"], "E": 1}`))
+	assert.NoError(err)
+	expected, err := frontend.NewWitness(&jsonAssignmentCircuit{X: 42, Y: [2]frontend.Variable{8000, 12}, E: 1}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	assert.True(reflect.DeepEqual(expected, w))
+
+	// missing secret value
+	_, err = witness.NewFromJSONAssignment(ecc.BN254, s, []byte(`{"X": "0x2a", "Y": ["0x1F40", "12"]}`))
+	assert.ErrorContains(err, "missing assignment for E")
+	assert.Equal(1, s.NbSecret, "the schema shouldn't be modified")
+
+	// missing public value
+	_, err = witness.NewFromJSONAssignment(ecc.BN254, s, []byte(`{"Y": ["0x1F40", "12"], "E": 1}`))
+	assert.ErrorContains(err, "missing assignment for X")
+
+	// unknown field
+	_, err = witness.NewFromJSONAssignment(ecc.BN254, s, []byte(`{"X": "0x2a", "Y": ["0x1F40", "12"], "E": 1, "F": 2}`))
+	assert.ErrorContains(err, `unknown field "F"`)
+
+	// invalid value
+	_, err = witness.NewFromJSONAssignment(ecc.BN254, s, []byte(`{"X": "0xzz", "Y": ["0x1F40", "12"], "E": 1}`))
+	assert.Error(err)
+}
@@ -27,6 +27,7 @@ import (
 	"math"
 	"math/big"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -87,7 +88,8 @@ func newSolver(cs *system, witness fr.Vector, opts ...csolver.Option) (*solver,
 	}
 
 	if len(missing) > 0 {
-		return nil, fmt.Errorf("solver missing hint(s): %v", missing)
+		sort.Strings(missing)
+		return nil, fmt.Errorf("solver missing hint(s): %v; register them with solver.RegisterHint or provide them with solver.WithHints", missing)
 	}
 
 	s := solver{
@@ -17,8 +17,12 @@
 package cs
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
 	"github.com/fxamacker/cbor/v2"
 	"io"
+	"math/big"
 	"time"
 
 	"github.com/consensys/gnark/backend/witness"
@@ -61,11 +65,16 @@ func newSystem(capacity int, t constraint.SystemType) *system {
 // Solve solves the constraint system with provided witness.
 // If it's a R1CS returns R1CSSolution
 // If it's a SparseR1CS returns SparseR1CSSolution
-func (cs *system) Solve(witness witness.Witness, opts ...csolver.Option) (any, error) {
+func (cs *system) Solve(w witness.Witness, opts ...csolver.Option) (any, error) {
 	log := logger.Logger().With().Int("nbConstraints", cs.GetNbConstraints()).Logger()
 	start := time.Now()
 
-	v := witness.Vector().(fr.Vector)
+	if unassigned := witness.Unassigned(w); len(unassigned) != 0 {
+		// the solver schedules the constraints assuming that all the inputs
+		// are known, it can't deduce the missing ones
+		return nil, fmt.Errorf("%w: values %v are missing, complete it with witness.Merge", witness.ErrPartialWitness, unassigned)
+	}
+	v := w.Vector().(fr.Vector)
 
 	// init the solver
 	solver, err := newSolver(cs, v, opts...)
@@ -208,6 +217,107 @@ func (cs *system) GetSparseR1Cs() []constraint.SparseR1C {
 	return toReturn
 }
 
+// StructuralID returns a digest of the layout of the SparseR1CS: its number of
+// public, secret and internal wires, and for each constraint its wires,
+// coefficients and commitment flag. It can be used as a cache key before
+// running the setup.
+//
+// The scalar field isn't hashed, and the coefficients are hashed as their
+// representative in (-q/2, q/2]. The small integer coefficients, which are the
+// usual ones, have the same representative for all the scalar fields, so that a
+// circuit compiled on different curves gives the same identifier as long as
+// its constant coefficients are small integers.
+func (cs *system) StructuralID() []byte {
+	h := sha256.New()
+	writeUint64 := func(v uint64) {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], v)
+		h.Write(buf[:])
+	}
+	writeUint64(uint64(len(cs.Public)))
+	writeUint64(uint64(len(cs.Secret)))
+	writeUint64(uint64(cs.NbInternalVariables))
+	writeUint64(uint64(cs.GetNbConstraints()))
+
+	// the centered representatives of the coefficients, encoded once
+	coeffs := make(map[uint32][]byte)
+	halfQ := new(big.Int).Rsh(fr.Modulus(), 1)
+	writeCoeff := func(id uint32) {
+		b, ok := coeffs[id]
+		if !ok {
+			var v big.Int
+			cs.Coefficients[id].BigInt(&v)
+			sign := byte(0)
+			if v.Cmp(halfQ) > 0 {
+				v.Sub(fr.Modulus(), &v)
+				sign = 1
+			}
+			b = append([]byte{sign}, v.Bytes()...)
+			coeffs[id] = b
+		}
+		writeUint64(uint64(len(b)))
+		h.Write(b)
+	}
+
+	it := cs.GetSparseR1CIterator()
+	for c := it.Next(); c != nil; c = it.Next() {
+		writeUint64(uint64(c.XA))
+		writeUint64(uint64(c.XB))
+		writeUint64(uint64(c.XC))
+		writeCoeff(c.QL)
+		writeCoeff(c.QR)
+		writeCoeff(c.QO)
+		writeCoeff(c.QM)
+		writeCoeff(c.QC)
+		writeUint64(uint64(c.Commitment))
+	}
+
+	return h.Sum(nil)
+}
+
+// DumpText writes to w the constraints of the SparseR1CS, one per line, as
+//
+//	cID: qL⋅xa + qR⋅xb + qO⋅xc + qM⋅(xa×xb) + qC == 0
+//
+// The public and secret wires are named after the circuit fields, and the
+// internal ones are numbered. When debug information is attached to a
+// constraint, its location (file:line) is appended to the line. It is meant for
+// debugging the construction of a circuit, not for serialization.
+func (cs *system) DumpText(w io.Writer) error {
+	it := cs.GetSparseR1CIterator()
+	for cID, c := 0, it.Next(); c != nil; cID, c = cID+1, it.Next() {
+		line := fmt.Sprintf("%d: %s", cID, c.String(cs))
+		if location, ok := cs.ConstraintLocation(cID); ok {
+			line += " // " + location
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaxDegree returns the highest degree in the wires of the gates of the
+// SparseR1CS: 2 if a gate has a qM⋅(xa×xb) term, 1 if the gates are all linear,
+// counting the placeholder gates of the public inputs, and 0 if there are no
+// gates or only constant ones.
+func (cs *system) MaxDegree() int {
+	degree := 0
+	if len(cs.Public) != 0 {
+		degree = 1
+	}
+	it := cs.GetSparseR1CIterator()
+	for c := it.Next(); c != nil; c = it.Next() {
+		switch {
+		case !cs.Coefficients[c.QM].IsZero():
+			return 2
+		case !cs.Coefficients[c.QL].IsZero(), !cs.Coefficients[c.QR].IsZero(), !cs.Coefficients[c.QO].IsZero(), c.Commitment != constraint.NOT:
+			degree = 1
+		}
+	}
+	return degree
+}
+
 // evaluateLROSmallDomain extracts the solver l, r, o, and returns it in lagrange form.
 // solver = [ public | secret | internal ]
 // TODO @gbotrel refactor; this seems to be a small util function for plonk
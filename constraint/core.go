@@ -3,6 +3,7 @@ package constraint
 import (
 	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 	"sync"
 
@@ -244,6 +245,17 @@ func (system *System) AddSecretVariable(name string) (idx int) {
 	return idx
 }
 
+// Hints returns the sorted IDs of the hint functions needed to solve the
+// constraint system.
+func (system *System) Hints() []solver.HintID {
+	ids := make([]solver.HintID, 0, len(system.MHintsDependencies))
+	for id := range system.MHintsDependencies {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
 func (system *System) AddSolverHint(f solver.Hint, id solver.HintID, input []LinearExpression, nbOutput int) (internalVariables []int, err error) {
 	if nbOutput <= 0 {
 		return nil, fmt.Errorf("hint function must return at least one output")
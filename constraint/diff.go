@@ -0,0 +1,87 @@
+package constraint
+
+import (
+	"strconv"
+)
+
+// UnknownLocation is the location under which DiffReport counts the constraints
+// without debug information.
+const UnknownLocation = "unknown"
+
+// DiffReport describes how the constraints of a constraint system b differ in
+// number from the ones of a constraint system a, per source location.
+type DiffReport struct {
+	NbConstraintsA, NbConstraintsB int
+
+	// Added maps a location (file:line) to the number of constraints b has in
+	// excess of a at this location, and Removed to the number of constraints b
+	// lacks. Locations with as many constraints in a and b are omitted.
+	Added, Removed map[string]int
+}
+
+// Diff compares the number of constraints of a and b per source location, as
+// recorded by the debug information attached to the constraints. The location
+// of a constraint is the one of the innermost call of the circuit code to the
+// frontend API which created it.
+//
+// Constraints without debug information are counted under UnknownLocation. The
+// frontend only attaches debug information to all the constraints when the
+// circuit is compiled with the debug build tag; otherwise most of them are
+// unknown and only the total is meaningful.
+func Diff(a, b SparseR1CS) DiffReport {
+	report := DiffReport{
+		NbConstraintsA: a.GetNbConstraints(),
+		NbConstraintsB: b.GetNbConstraints(),
+		Added:          make(map[string]int),
+		Removed:        make(map[string]int),
+	}
+
+	locations := constraintsPerLocation(a)
+	for location, n := range constraintsPerLocation(b) {
+		locations[location] -= n
+	}
+	for location, n := range locations {
+		if n > 0 {
+			report.Removed[location] = n
+		} else if n < 0 {
+			report.Added[location] = -n
+		}
+	}
+
+	return report
+}
+
+// constraintsPerLocation returns the number of constraints of cs per location.
+func constraintsPerLocation(cs SparseR1CS) map[string]int {
+	res := make(map[string]int)
+	system, ok := cs.(interface{ system() *System })
+	if !ok {
+		res[UnknownLocation] = cs.GetNbConstraints()
+		return res
+	}
+	s := system.system()
+	for cID := 0; cID < s.GetNbConstraints(); cID++ {
+		location, ok := s.ConstraintLocation(cID)
+		if !ok {
+			location = UnknownLocation
+		}
+		res[location]++
+	}
+	return res
+}
+
+func (system *System) system() *System {
+	return system
+}
+
+// ConstraintLocation returns the file:line location of the constraint cID,
+// where it was added in the circuit, or false if it has no debug information.
+func (system *System) ConstraintLocation(cID int) (string, bool) {
+	dID, ok := system.MDebug[cID]
+	if !ok || len(system.DebugInfo[dID].Stack) == 0 {
+		return "", false
+	}
+	location := system.SymbolTable.Locations[system.DebugInfo[dID].Stack[0]]
+	function := system.SymbolTable.Functions[location.FunctionID]
+	return function.Filename + ":" + strconv.Itoa(int(location.Line)), true
+}
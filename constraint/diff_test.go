@@ -0,0 +1,62 @@
+package constraint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/stretchr/testify/require"
+)
+
+type diffCircuit struct {
+	X, Y      frontend.Variable
+	nbSquares int
+}
+
+func (c *diffCircuit) Define(api frontend.API) error {
+	x := c.X
+	for i := 0; i < c.nbSquares; i++ {
+		x = api.Mul(x, x)
+	}
+	api.AssertIsEqual(x, c.Y)
+	return nil
+}
+
+// attachDebugInfo attaches to the constraints cIDs a debug information whose
+// location is the call site of attachDebugInfo.
+func attachDebugInfo(cs constraint.ConstraintSystem, cIDs []int) {
+	cs.AttachDebugInfo(cs.NewDebugInfo("diff"), cIDs)
+}
+
+func TestDiff(t *testing.T) {
+	assert := require.New(t)
+
+	compile := func(nbSquares int) constraint.SparseR1CS {
+		ccs, err := frontend.Compile(ecc.BN254.ScalarField(), scs.NewBuilder, &diffCircuit{nbSquares: nbSquares})
+		assert.NoError(err)
+		return ccs.(constraint.SparseR1CS)
+	}
+
+	a, b := compile(2), compile(5)
+	report := constraint.Diff(a, b)
+	assert.Equal(a.GetNbConstraints(), report.NbConstraintsA)
+	assert.Equal(b.GetNbConstraints(), report.NbConstraintsB)
+	assert.Empty(report.Removed)
+	assert.Equal(map[string]int{constraint.UnknownLocation: 3}, report.Added)
+
+	assert.Empty(constraint.Diff(a, a).Added)
+	assert.Empty(constraint.Diff(a, a).Removed)
+
+	// the constraints with debug information are grouped by location
+	attachDebugInfo(b, []int{0, 1, 2, 3})
+	report = constraint.Diff(a, b)
+	assert.Equal(1, report.Removed[constraint.UnknownLocation])
+	assert.Len(report.Added, 1)
+	for location, n := range report.Added {
+		assert.True(strings.HasPrefix(location, "diff_test.go:"), location)
+		assert.Equal(4, n)
+	}
+}
@@ -0,0 +1,46 @@
+package constraint_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark/constraint"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpText(t *testing.T) {
+	assert := require.New(t)
+
+	scs := cs.NewSparseR1CS(0)
+	blueprint := scs.AddBlueprint(&constraint.BlueprintGenericSparseR1C{})
+	Y := scs.AddPublicVariable("Y")
+	X := scs.AddSecretVariable("X")
+	v0 := scs.AddInternalVariable()
+
+	// X² == v0
+	scs.AddSparseR1C(constraint.SparseR1C{
+		XA: uint32(X),
+		XB: uint32(X),
+		XC: uint32(v0),
+		QO: constraint.CoeffIdMinusOne,
+		QM: constraint.CoeffIdOne,
+	}, blueprint)
+	// v0 + 5 == Y
+	cID := scs.AddSparseR1C(constraint.SparseR1C{
+		XA: uint32(v0),
+		XC: uint32(Y),
+		QL: constraint.CoeffIdOne,
+		QO: constraint.CoeffIdMinusOne,
+		QC: scs.AddCoeff(scs.FromInterface(5)),
+	}, blueprint)
+	attachDebugInfo(scs, []int{cID})
+
+	var sb strings.Builder
+	assert.NoError(scs.DumpText(&sb))
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	assert.Len(lines, 2)
+	assert.Equal("0: 0 + 0 + -1⋅v0 + 1⋅(X×X) + 0 == 0", lines[0])
+	assert.True(strings.HasPrefix(lines[1], "1: v0 + 0 + -1⋅Y + 5 == 0 // "), lines[1])
+	assert.Contains(lines[1], "dump_text_test.go:")
+}
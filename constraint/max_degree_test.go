@@ -0,0 +1,40 @@
+package constraint_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/constraint"
+	cs "github.com/consensys/gnark/constraint/bn254"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxDegree(t *testing.T) {
+	assert := require.New(t)
+
+	scs := cs.NewSparseR1CS(0)
+	assert.Equal(0, scs.MaxDegree())
+
+	blueprint := scs.AddBlueprint(&constraint.BlueprintGenericSparseR1C{})
+	X := scs.AddSecretVariable("X")
+	v0 := scs.AddInternalVariable()
+
+	// X + 1 == v0
+	scs.AddSparseR1C(constraint.SparseR1C{
+		XA: uint32(X),
+		XC: uint32(v0),
+		QL: constraint.CoeffIdOne,
+		QO: constraint.CoeffIdMinusOne,
+		QC: constraint.CoeffIdOne,
+	}, blueprint)
+	assert.Equal(1, scs.MaxDegree())
+
+	// X² == v0
+	scs.AddSparseR1C(constraint.SparseR1C{
+		XA: uint32(X),
+		XB: uint32(X),
+		XC: uint32(v0),
+		QO: constraint.CoeffIdMinusOne,
+		QM: constraint.CoeffIdOne,
+	}, blueprint)
+	assert.Equal(2, scs.MaxDegree())
+}
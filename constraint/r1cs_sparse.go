@@ -14,6 +14,8 @@
 
 package constraint
 
+import "io"
+
 type SparseR1CS interface {
 	ConstraintSystem
 
@@ -27,6 +29,20 @@ type SparseR1CS interface {
 
 	// GetSparseR1CIterator returns an SparseR1CIterator to iterate on the SparseR1C constraints of the system.
 	GetSparseR1CIterator() SparseR1CIterator
+
+	// StructuralID returns a digest of the wiring and coefficients of the
+	// constraints, which doesn't depend on the scalar field for circuits with
+	// small integer coefficients.
+	StructuralID() []byte
+
+	// DumpText writes the constraints to w in a human-readable form, one per
+	// line, with the location where they were created when it is known. It is
+	// meant for debugging.
+	DumpText(w io.Writer) error
+
+	// MaxDegree returns the highest degree in the wires of the gates, which
+	// determines the size of the domain on which the PLONK quotient is computed.
+	MaxDegree() int
 }
 
 // SparseR1CIterator facilitates iterating through SparseR1C constraints.
@@ -0,0 +1,39 @@
+package constraint_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/stretchr/testify/require"
+)
+
+type structuralIDCircuit struct {
+	X, Y     frontend.Variable `gnark:",public"`
+	constant int
+}
+
+func (c *structuralIDCircuit) Define(api frontend.API) error {
+	x := api.Mul(c.X, c.X)
+	api.AssertIsEqual(api.Sub(x, c.constant), c.Y)
+	return nil
+}
+
+func TestStructuralID(t *testing.T) {
+	assert := require.New(t)
+
+	structuralID := func(curve ecc.ID, constant int) []byte {
+		ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &structuralIDCircuit{constant: constant})
+		assert.NoError(err)
+		return ccs.(constraint.SparseR1CS).StructuralID()
+	}
+
+	id := structuralID(ecc.BN254, 5)
+	assert.Equal(id, structuralID(ecc.BN254, 5), "compilation should be deterministic")
+	for _, curve := range []ecc.ID{ecc.BLS12_381, ecc.BLS12_377, ecc.BW6_761} {
+		assert.Equal(id, structuralID(curve, 5), curve.String())
+	}
+	assert.NotEqual(id, structuralID(ecc.BN254, 7), "the constants should be hashed")
+}
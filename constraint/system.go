@@ -23,6 +23,9 @@ type ConstraintSystem interface {
 	// Solve attempts to solve the constraint system using provided witness.
 	// Returns an error if the witness does not allow all the constraints to be satisfied.
 	// Returns a typed solution (R1CSSolution or SparseR1CSSolution) and nil otherwise.
+	// A partially assigned witness must be completed first, for example with
+	// witness.Merge: Solve returns an error wrapping witness.ErrPartialWitness
+	// otherwise.
 	Solve(witness witness.Witness, opts ...solver.Option) (any, error)
 
 	// GetNbVariables return number of internal, secret and public Variables
@@ -50,6 +53,11 @@ type ConstraintSystem interface {
 	// Otherwise, the provided id will be used to register the hint with,
 	AddSolverHint(f solver.Hint, id solver.HintID, input []LinearExpression, nbOutput int) (internalVariables []int, err error)
 
+	// Hints returns the sorted IDs of the hint functions needed to solve the
+	// constraint system. They must be registered with [solver.RegisterHint] or
+	// provided to the solver with [solver.WithHints].
+	Hints() []solver.HintID
+
 	AddCommitment(c Commitment) error
 	GetCommitments() Commitments
 	AddGkr(gkr GkrInfo) error
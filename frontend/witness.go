@@ -36,18 +36,25 @@ func NewWitness(assignment Circuit, field *big.Int, opts ...WitnessOption) (witn
 
 	// write the public | secret values in a chan
 	chValues := make(chan any)
+	value := func(tValue reflect.Value) any {
+		v := tValue.Interface()
+		if v == nil && opt.partial {
+			return witness.NotAssigned
+		}
+		return v
+	}
 	go func() {
 		defer close(chValues)
 		schema.Walk(assignment, tVariable, func(leaf schema.LeafInfo, tValue reflect.Value) error {
 			if leaf.Visibility == schema.Public {
-				chValues <- tValue.Interface()
+				chValues <- value(tValue)
 			}
 			return nil
 		})
 		if !opt.publicOnly {
 			schema.Walk(assignment, tVariable, func(leaf schema.LeafInfo, tValue reflect.Value) error {
 				if leaf.Visibility == schema.Secret {
-					chValues <- tValue.Interface()
+					chValues <- value(tValue)
 				}
 				return nil
 			})
@@ -87,6 +94,7 @@ type WitnessOption func(*witnessConfig) error
 
 type witnessConfig struct {
 	publicOnly bool
+	partial    bool
 }
 
 // PublicOnly enables to instantiate a witness with the public part only of the assignment
@@ -96,3 +104,13 @@ func PublicOnly() WitnessOption {
 		return nil
 	}
 }
+
+// PartialAssignment enables to instantiate a partial witness from an assignment
+// with nil fields. The values of the nil fields are left unassigned, see
+// witness.NotAssigned.
+func PartialAssignment() WitnessOption {
+	return func(opt *witnessConfig) error {
+		opt.partial = true
+		return nil
+	}
+}
@@ -0,0 +1,147 @@
+package merkle
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// MultiProof holds the nodes needed to verify that several leaves belong to a
+// Merkle tree, in addition to the nodes recomputed from the leaves.
+type MultiProof struct {
+
+	// Depth of the Merkle tree, which has 2ᴰᵉᵖᵗʰ leaves
+	Depth int
+
+	// Layout the positions of leaves laid out as the opened ones, for
+	// instance the positions for which the proof was computed. It is fixed
+	// when compiling the circuit, and determines which nodes are shared by the
+	// paths: the path of the i-th opened leaf meets the path of the j-th one
+	// at the same level, and on the same side, as the paths of Layout[i] and
+	// Layout[j].
+	Layout []uint64
+
+	// Nodes the siblings of the nodes on the paths from the opened leaves to
+	// the root which are not on one of these paths. They are ordered level by
+	// level starting from the leaves, and by position in Layout of the node
+	// they are the sibling of.
+	Nodes []frontend.Variable
+}
+
+// VerifyMultiProof asserts that the leaves are the leaves at the given indices
+// of the Merkle tree of the given root. The leaves are hashed, and the nodes
+// computed, as in [MerkleProof.VerifyProof].
+//
+// Unlike verifying a Merkle proof for each leaf, the nodes shared by the paths
+// of several leaves are computed once. Which nodes are shared is given by
+// proof.Layout, as the number of hashes of the circuit can't depend on the
+// indices: the indices are constrained to be laid out as proof.Layout, and the
+// path of each leaf is selected by the bits of its index. The number of nodes
+// of proof is given by [NbMultiProofNodes].
+func VerifyMultiProof(api frontend.API, h hash.FieldHasher, root frontend.Variable, leaves, indices []frontend.Variable, proof MultiProof) error {
+	if len(leaves) != len(indices) || len(leaves) != len(proof.Layout) {
+		return errors.New("there must be as many leaves as indices and as positions in the layout")
+	}
+	if nbNodes, err := NbMultiProofNodes(proof.Depth, proof.Layout); err != nil {
+		return err
+	} else if nbNodes != len(proof.Nodes) {
+		return fmt.Errorf("expected %d nodes in the proof, got %d", nbNodes, len(proof.Nodes))
+	}
+
+	// node is a node of the current level, with the bits of the index of one
+	// of the leaves below it. The bits of the other leaves below it are equal
+	// from the current level up.
+	type node struct {
+		value frontend.Variable
+		bits  []frontend.Variable
+	}
+
+	// nodes of the current level, by position in the layout
+	level := make(map[uint64]node, len(leaves))
+	for i := range leaves {
+		level[proof.Layout[i]] = node{
+			value: leafSum(api, h, leaves[i]),
+			bits:  api.ToBinary(indices[i], proof.Depth),
+		}
+	}
+
+	next := 0
+	for d := 0; d < proof.Depth; d++ {
+		parents := make(map[uint64]node, len(level))
+		for _, p := range sortedPositions(level) {
+			if _, ok := parents[p/2]; ok {
+				continue // computed with its sibling
+			}
+			cur := level[p]
+			sibling, ok := level[p^1]
+			if !ok {
+				// the index selects the side of the node
+				s := proof.Nodes[next]
+				next++
+				left := api.Select(cur.bits[d], s, cur.value)
+				right := api.Select(cur.bits[d], cur.value, s)
+				parents[p/2] = node{value: nodeSum(api, h, left, right), bits: cur.bits}
+				continue
+			}
+			// both nodes are opened, p is the left one as p is even. Their
+			// indices must be laid out the same way and have the same parent.
+			api.AssertIsEqual(cur.bits[d], 0)
+			api.AssertIsEqual(sibling.bits[d], 1)
+			if d+1 < proof.Depth {
+				api.AssertIsEqual(api.FromBinary(cur.bits[d+1:]...), api.FromBinary(sibling.bits[d+1:]...))
+			}
+			parents[p/2] = node{value: nodeSum(api, h, cur.value, sibling.value), bits: cur.bits}
+		}
+		level = parents
+	}
+
+	api.AssertIsEqual(level[0].value, root)
+	return nil
+}
+
+// NbMultiProofNodes returns the number of nodes of the MultiProof of the leaves
+// laid out as the given positions in a Merkle tree of the given depth. It
+// returns an error if the positions are not distinct or not in the tree.
+func NbMultiProofNodes(depth int, positions []uint64) (int, error) {
+	if len(positions) == 0 {
+		return 0, errors.New("no leaf to open")
+	}
+	if depth < 0 || depth >= 64 {
+		return 0, fmt.Errorf("invalid depth %d", depth)
+	}
+	level := make(map[uint64]struct{}, len(positions))
+	for _, p := range positions {
+		if p>>depth != 0 {
+			return 0, fmt.Errorf("position %d is out of a tree of depth %d", p, depth)
+		}
+		if _, ok := level[p]; ok {
+			return 0, fmt.Errorf("position %d is opened twice", p)
+		}
+		level[p] = struct{}{}
+	}
+
+	nbNodes := 0
+	for d := 0; d < depth; d++ {
+		parents := make(map[uint64]struct{}, len(level))
+		for p := range level {
+			if _, ok := level[p^1]; !ok {
+				nbNodes++
+			}
+			parents[p/2] = struct{}{}
+		}
+		level = parents
+	}
+	return nbNodes, nil
+}
+
+func sortedPositions[T any](level map[uint64]T) []uint64 {
+	res := make([]uint64, 0, len(level))
+	for p := range level {
+		res = append(res, p)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	return res
+}
@@ -0,0 +1,203 @@
+package merkle
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type multiProofCircuit struct {
+	Root    frontend.Variable
+	Leaves  []frontend.Variable
+	Indices []frontend.Variable
+	Proof   MultiProof
+}
+
+func (c *multiProofCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	return VerifyMultiProof(api, &h, c.Root, c.Leaves, c.Indices, c.Proof)
+}
+
+// nativeTree returns the levels of the MiMC Merkle tree of the leaves, from
+// the leaves to the root.
+func nativeTree(leaves [][]byte) [][][]byte {
+	h := hash.MIMC_BN254.New()
+	sum := func(data ...[]byte) []byte {
+		h.Reset()
+		for _, d := range data {
+			h.Write(d)
+		}
+		return h.Sum(nil)
+	}
+	level := make([][]byte, len(leaves))
+	for i := range leaves {
+		level[i] = sum(leaves[i])
+	}
+	tree := [][][]byte{level}
+	for len(level) > 1 {
+		parents := make([][]byte, len(level)/2)
+		for i := range parents {
+			parents[i] = sum(level[2*i], level[2*i+1])
+		}
+		tree = append(tree, parents)
+		level = parents
+	}
+	return tree
+}
+
+// nativeMultiProof returns the nodes of the multi-proof of the leaves at
+// indices, laid out as layout, in the order expected by VerifyMultiProof.
+func nativeMultiProof(tree [][][]byte, layout, indices []uint64) [][]byte {
+	var nodes [][]byte
+	// index of the node at the same position as a node of the layout
+	level := make(map[uint64]uint64)
+	for i, p := range layout {
+		level[p] = indices[i]
+	}
+	for d := 0; d < len(tree)-1; d++ {
+		parents := make(map[uint64]uint64)
+		for _, p := range sortedPositions(level) {
+			if _, ok := level[p^1]; !ok {
+				nodes = append(nodes, tree[d][level[p]^1])
+			}
+			parents[p/2] = level[p] / 2
+		}
+		level = parents
+	}
+	return nodes
+}
+
+func TestVerifyMultiProof(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 4
+
+	leaves := make([][]byte, 1<<depth)
+	var buf bytes.Buffer
+	for i := range leaves {
+		var e fr.Element
+		e.SetRandom()
+		b := e.Bytes()
+		leaves[i] = b[:]
+		buf.Write(b[:])
+	}
+	tree := nativeTree(leaves)
+	root := tree[depth][0]
+
+	// the tree matches the one of gnark-crypto
+	expectedRoot, _, _, err := merkletree.BuildReaderProof(&buf, hash.MIMC_BN254.New(), fr.Bytes, 0)
+	assert.NoError(err)
+	assert.Equal(expectedRoot, root)
+
+	for _, tc := range []struct{ layout, indices []uint64 }{
+		{[]uint64{5}, []uint64{5}},
+		{[]uint64{5}, []uint64{10}},
+		{[]uint64{2, 3}, []uint64{2, 3}},
+		{[]uint64{2, 3}, []uint64{12, 13}},
+		{[]uint64{0, 9, 15}, []uint64{0, 9, 15}},
+		{[]uint64{0, 9, 15}, []uint64{6, 10, 13}},
+		{[]uint64{7, 6, 1, 12}, []uint64{7, 6, 1, 12}},
+		{[]uint64{7, 6, 1, 12}, []uint64{5, 4, 3, 14}},
+	} {
+		nodes := nativeMultiProof(tree, tc.layout, tc.indices)
+		nbNodes, err := NbMultiProofNodes(depth, tc.layout)
+		assert.NoError(err)
+		assert.Equal(len(nodes), nbNodes)
+
+		circuit := multiProofCircuit{
+			Leaves:  make([]frontend.Variable, len(tc.layout)),
+			Indices: make([]frontend.Variable, len(tc.layout)),
+			Proof:   MultiProof{Depth: depth, Layout: tc.layout, Nodes: make([]frontend.Variable, nbNodes)},
+		}
+		witness := multiProofCircuit{
+			Root:    root,
+			Leaves:  make([]frontend.Variable, len(tc.layout)),
+			Indices: make([]frontend.Variable, len(tc.layout)),
+			Proof:   MultiProof{Depth: depth, Nodes: make([]frontend.Variable, nbNodes)},
+		}
+		for i, p := range tc.indices {
+			witness.Leaves[i] = leaves[p]
+			witness.Indices[i] = p
+		}
+		for i := range nodes {
+			witness.Proof.Nodes[i] = nodes[i]
+		}
+		assert.NoError(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()), tc)
+
+		// the leaves must be at their indices
+		witness.Indices[0] = tc.indices[0] ^ 1
+		assert.Error(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()), tc)
+		witness.Indices[0] = tc.indices[0]
+
+		// swapping two leaves must fail
+		if len(tc.layout) > 1 {
+			witness.Leaves[0], witness.Leaves[1] = witness.Leaves[1], witness.Leaves[0]
+			assert.Error(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()), tc)
+			witness.Leaves[0], witness.Leaves[1] = witness.Leaves[1], witness.Leaves[0]
+
+			// as well as swapping the leaves and their indices, which
+			// doesn't match the layout
+			witness.Leaves[0], witness.Leaves[1] = witness.Leaves[1], witness.Leaves[0]
+			witness.Indices[0], witness.Indices[1] = witness.Indices[1], witness.Indices[0]
+			assert.Error(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()), tc)
+		}
+	}
+
+	// the indices must be in the tree
+	circuit := multiProofCircuit{
+		Leaves:  make([]frontend.Variable, 1),
+		Indices: make([]frontend.Variable, 1),
+		Proof:   MultiProof{Depth: depth, Layout: []uint64{5}, Nodes: make([]frontend.Variable, depth)},
+	}
+	witness := multiProofCircuit{
+		Root:    root,
+		Leaves:  []frontend.Variable{leaves[5]},
+		Indices: []frontend.Variable{5 + 1<<depth},
+		Proof:   MultiProof{Depth: depth, Nodes: make([]frontend.Variable, depth)},
+	}
+	for i, n := range nativeMultiProof(tree, []uint64{5}, []uint64{5}) {
+		witness.Proof.Nodes[i] = n
+	}
+	assert.Error(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()))
+
+	_, err = NbMultiProofNodes(depth, []uint64{3, 3})
+	assert.Error(err, "duplicate position")
+	_, err = NbMultiProofNodes(depth, []uint64{1 << depth})
+	assert.Error(err, "position out of the tree")
+}
+
+func TestVerifyMultiProofSharesNodes(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 8
+
+	// two neighbouring leaves share all the nodes of their paths but the leaves
+	positions := []uint64{10, 11}
+	nbNodes, err := NbMultiProofNodes(depth, positions)
+	assert.NoError(err)
+	assert.Equal(depth-1, nbNodes)
+
+	multi := multiProofCircuit{
+		Leaves:  make([]frontend.Variable, len(positions)),
+		Indices: make([]frontend.Variable, len(positions)),
+		Proof:   MultiProof{Depth: depth, Layout: positions, Nodes: make([]frontend.Variable, nbNodes)},
+	}
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &multi)
+	assert.NoError(err)
+
+	var single MerkleProofTest
+	single.M.Path = make([]frontend.Variable, depth+1)
+	ccsSingle, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &single)
+	assert.NoError(err)
+
+	assert.Less(ccs.GetNbConstraints(), 2*ccsSingle.GetNbConstraints()*2/3)
+}
@@ -0,0 +1,54 @@
+package merkle
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// VerifyProofSHA256 asserts that leaf is the leaf at position index of the
+// binary Merkle tree of the given root, where the nodes are computed as
+// SHA256(left || right). This is the Merkle tree used by the Ethereum deposit
+// contract and by the SSZ merkleization of the beacon chain.
+//
+// The nodes are 32 bytes long. path holds the siblings of the nodes on the
+// path from the leaf to the root, starting from the sibling of the leaf. The
+// i-th bit of index (little endian) is 1 if the node at depth len(path)-i is
+// a right child.
+func VerifyProofSHA256(api frontend.API, root, leaf []uints.U8, path [][]uints.U8, index frontend.Variable) error {
+	if len(root) != 32 || len(leaf) != 32 {
+		return fmt.Errorf("root and leaf must be 32 bytes long")
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	binIndex := api.ToBinary(index, len(path))
+
+	node := leaf
+	left := make([]uints.U8, 32)
+	right := make([]uints.U8, 32)
+	for i := range path {
+		if len(path[i]) != 32 {
+			return fmt.Errorf("path node %d must be 32 bytes long", i)
+		}
+		for j := range node {
+			left[j] = uints.U8{Val: api.Select(binIndex[i], path[i][j].Val, node[j].Val)}
+			right[j] = uints.U8{Val: api.Select(binIndex[i], node[j].Val, path[i][j].Val)}
+		}
+		h, err := sha2.New(api)
+		if err != nil {
+			return err
+		}
+		h.Write(left)
+		h.Write(right)
+		node = h.Sum()
+	}
+
+	for i := range root {
+		uapi.ByteAssertEq(root[i], node[i])
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package merkle
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type merkleSHA256Circuit struct {
+	Root  [32]uints.U8
+	Leaf  [32]uints.U8
+	Path  [][32]uints.U8
+	Index frontend.Variable
+}
+
+func (c *merkleSHA256Circuit) Define(api frontend.API) error {
+	path := make([][]uints.U8, len(c.Path))
+	for i := range c.Path {
+		path[i] = c.Path[i][:]
+	}
+	return VerifyProofSHA256(api, c.Root[:], c.Leaf[:], path, c.Index)
+}
+
+func newMerkleSHA256Assignment(root, leaf []byte, path [][]byte, index uint64) *merkleSHA256Circuit {
+	res := &merkleSHA256Circuit{Index: index, Path: make([][32]uints.U8, len(path))}
+	copy(res.Root[:], uints.NewU8Array(root))
+	copy(res.Leaf[:], uints.NewU8Array(leaf))
+	for i := range path {
+		copy(res.Path[i][:], uints.NewU8Array(path[i]))
+	}
+	return res
+}
+
+func TestVerifyProofSHA256ZeroHashes(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// zero hashes of the Ethereum deposit contract: zeroHashes[i+1] = SHA256(zeroHashes[i] || zeroHashes[i])
+	zeroHashes := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b",
+		"db56114e00fdd4c1f85c892bf35ac9a89289aaecb1ebd0a96cde606a748b5d71",
+		"c78009fdf07fc56a11f122370658a353aaa542ed63e44c4bc15ff4cd105ab33c",
+	}
+	root, _ := hex.DecodeString("536d98837f2dd165a55d5eeae91485954472d56f246df256bf3cae19352a123c")
+	path := make([][]byte, len(zeroHashes))
+	for i := range zeroHashes {
+		path[i], _ = hex.DecodeString(zeroHashes[i])
+	}
+
+	circuit := &merkleSHA256Circuit{Path: make([][32]uints.U8, len(path))}
+	assert.CheckCircuit(circuit,
+		test.WithValidAssignment(newMerkleSHA256Assignment(root, path[0], path, 5)),
+		test.WithCurves(ecc.BN254), test.NoProverChecks())
+}
+
+func TestVerifyProofSHA256(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 3
+
+	// build a random tree
+	leaves := make([][]byte, 1<<depth)
+	for i := range leaves {
+		leaves[i] = make([]byte, 32)
+		_, err := rand.Read(leaves[i])
+		assert.NoError(err)
+	}
+	levels := [][][]byte{leaves}
+	for d := 0; d < depth; d++ {
+		prev := levels[d]
+		next := make([][]byte, len(prev)/2)
+		for i := range next {
+			h := sha256.Sum256(append(append([]byte{}, prev[2*i]...), prev[2*i+1]...))
+			next[i] = h[:]
+		}
+		levels = append(levels, next)
+	}
+	root := levels[depth][0]
+
+	const index = 6
+	path := make([][]byte, depth)
+	for d := 0; d < depth; d++ {
+		path[d] = levels[d][(index>>d)^1]
+	}
+
+	circuit := &merkleSHA256Circuit{Path: make([][32]uints.U8, depth)}
+	assert.CheckCircuit(circuit,
+		test.WithValidAssignment(newMerkleSHA256Assignment(root, leaves[index], path, index)),
+		test.WithInvalidAssignment(newMerkleSHA256Assignment(root, leaves[index], path, index^1)),
+		test.WithInvalidAssignment(newMerkleSHA256Assignment(root, leaves[index-1], path, index)),
+		test.WithCurves(ecc.BN254), test.NoSerializationChecks())
+}
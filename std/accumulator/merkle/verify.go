@@ -86,6 +86,11 @@ func nodeSum(api frontend.API, h hash.FieldHasher, a, b frontend.Variable) front
 // true if the first element of the proof set is a leaf of data in the Merkle
 // root. False is returned if the proof set or Merkle root is nil, and if
 // 'numLeaves' equals 0.
+//
+// VerifyProof is generic over the hash function: the same method verifies
+// proofs of trees built with MiMC or with any other algebraic hash, e.g.
+// Poseidon, as long as its gadget implements [hash.FieldHasher] and the native
+// tree computes the nodes as H(left, right) and the leaves as H(data).
 func (mp *MerkleProof) VerifyProof(api frontend.API, h hash.FieldHasher, leaf frontend.Variable) {
 
 	depth := len(mp.Path) - 1
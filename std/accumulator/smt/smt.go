@@ -0,0 +1,61 @@
+// Package smt provides ZKP-circuit functions to verify inclusion and
+// non-inclusion proofs in a sparse Merkle tree.
+//
+// The tree has a fixed depth d and 2ᵈ leaves, the leaf at index key storing
+// the value associated to key. Over a field hash H, the nodes are defined as
+// follows:
+//
+//   - an empty leaf is 0, and the leaf of key holding value is H(key, value);
+//   - an internal node is H(left, right).
+//
+// The root of a subtree of height i whose leaves are all empty is thus the
+// default node D(i), with D(0) = 0 and D(i+1) = H(D(i), D(i)).
+//
+// A proof for key is the list of the d siblings of the nodes on the path from
+// the leaf of key to the root, starting at the leaf level. The i-th bit of key
+// (little-endian) tells whether the node at height i is the right (1) or left
+// (0) child of its parent. Siblings which are the roots of empty subtrees are
+// the default nodes, and are provided as any other sibling.
+package smt
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// VerifyInclusion asserts that key is associated to value in the sparse Merkle
+// tree of the given root, siblings being the proof for key. The depth of the
+// tree is len(siblings), and key must be less than 2^len(siblings).
+func VerifyInclusion(api frontend.API, h hash.FieldHasher, root, key, value frontend.Variable, siblings []frontend.Variable) {
+	h.Reset()
+	h.Write(key, value)
+	leaf := h.Sum()
+	api.AssertIsEqual(computeRoot(api, h, leaf, key, siblings), root)
+}
+
+// VerifyNonInclusion asserts that key is not associated to any value in the
+// sparse Merkle tree of the given root, that is that its leaf is empty,
+// siblings being the proof for key. The depth of the tree is len(siblings),
+// and key must be less than 2^len(siblings).
+func VerifyNonInclusion(api frontend.API, h hash.FieldHasher, root, key frontend.Variable, siblings []frontend.Variable) {
+	api.AssertIsEqual(computeRoot(api, h, 0, key, siblings), root)
+}
+
+// computeRoot returns the root of the tree obtained by hashing leaf up to the
+// root along the path of key.
+func computeRoot(api frontend.API, h hash.FieldHasher, leaf, key frontend.Variable, siblings []frontend.Variable) frontend.Variable {
+	path := bits.ToBinary(api, key, bits.WithNbDigits(len(siblings)))
+
+	node := leaf
+	for i := range siblings {
+		left := api.Select(path[i], siblings[i], node)
+		right := api.Select(path[i], node, siblings[i])
+		h.Reset()
+		h.Write(left, right)
+		node = h.Sum()
+	}
+	h.Reset()
+
+	return node
+}
@@ -0,0 +1,164 @@
+package smt
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	gmimc "github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+const depth = 16
+
+// nativeSMT is a sparse Merkle tree over the BN254 MiMC hash, as described in
+// the package documentation.
+type nativeSMT struct {
+	values   map[uint64]fr.Element
+	defaults [depth + 1]fr.Element
+}
+
+func newNativeSMT() *nativeSMT {
+	t := &nativeSMT{values: make(map[uint64]fr.Element)}
+	for i := 0; i < depth; i++ {
+		t.defaults[i+1] = nativeHash(t.defaults[i], t.defaults[i])
+	}
+	return t
+}
+
+func nativeHash(a, b fr.Element) fr.Element {
+	h := mimc.NewMiMC()
+	ab, bb := a.Bytes(), b.Bytes()
+	h.Write(ab[:])
+	h.Write(bb[:])
+	var res fr.Element
+	res.SetBytes(h.Sum(nil))
+	return res
+}
+
+// levels returns the non-empty nodes of the tree, by height.
+func (t *nativeSMT) levels() [depth + 1]map[uint64]fr.Element {
+	var levels [depth + 1]map[uint64]fr.Element
+	levels[0] = make(map[uint64]fr.Element)
+	for key, value := range t.values {
+		var k fr.Element
+		k.SetUint64(key)
+		levels[0][key] = nativeHash(k, value)
+	}
+	for i := 0; i < depth; i++ {
+		levels[i+1] = make(map[uint64]fr.Element)
+		for idx := range levels[i] {
+			left, right := t.node(levels[i], i, idx&^1), t.node(levels[i], i, idx|1)
+			levels[i+1][idx>>1] = nativeHash(left, right)
+		}
+	}
+	return levels
+}
+
+func (t *nativeSMT) node(level map[uint64]fr.Element, height int, idx uint64) fr.Element {
+	if n, ok := level[idx]; ok {
+		return n
+	}
+	return t.defaults[height]
+}
+
+// proof returns the root of the tree and the siblings on the path of key.
+func (t *nativeSMT) proof(key uint64) (root fr.Element, siblings [depth]frontend.Variable) {
+	levels := t.levels()
+	for i := 0; i < depth; i++ {
+		siblings[i] = t.node(levels[i], i, (key>>i)^1)
+	}
+	return t.node(levels[depth], depth, 0), siblings
+}
+
+type inclusionCircuit struct {
+	Root, Key, Value frontend.Variable
+	Siblings         [depth]frontend.Variable
+}
+
+func (c *inclusionCircuit) Define(api frontend.API) error {
+	h, err := gmimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	VerifyInclusion(api, &h, c.Root, c.Key, c.Value, c.Siblings[:])
+	return nil
+}
+
+type nonInclusionCircuit struct {
+	Root, Key frontend.Variable
+	Siblings  [depth]frontend.Variable
+}
+
+func (c *nonInclusionCircuit) Define(api frontend.API) error {
+	h, err := gmimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	VerifyNonInclusion(api, &h, c.Root, c.Key, c.Siblings[:])
+	return nil
+}
+
+func TestSMT(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	tree := newNativeSMT()
+	for _, key := range []uint64{0, 1, 42, 1 << 15, 1<<16 - 1} {
+		var v fr.Element
+		v.SetRandom()
+		tree.values[key] = v
+	}
+	const key, absent = uint64(42), uint64(43)
+
+	inclusion := func(key uint64, value fr.Element) *inclusionCircuit {
+		root, siblings := tree.proof(key)
+		return &inclusionCircuit{Root: root, Key: key, Value: value, Siblings: siblings}
+	}
+	nonInclusion := func(key uint64) *nonInclusionCircuit {
+		root, siblings := tree.proof(key)
+		return &nonInclusionCircuit{Root: root, Key: key, Siblings: siblings}
+	}
+
+	// inclusion
+	oldValue := tree.values[key]
+	wrongKey := inclusion(key, oldValue)
+	wrongKey.Key = absent
+	assert.CheckCircuit(&inclusionCircuit{},
+		test.WithValidAssignment(inclusion(key, oldValue)),
+		test.WithValidAssignment(inclusion(0, tree.values[0])),
+		test.WithValidAssignment(inclusion(1<<16-1, tree.values[1<<16-1])),
+		test.WithInvalidAssignment(inclusion(key, fr.NewElement(1))),
+		test.WithInvalidAssignment(wrongKey),
+		test.WithCurves(ecc.BN254),
+	)
+
+	// non-inclusion
+	assert.CheckCircuit(&nonInclusionCircuit{},
+		test.WithValidAssignment(nonInclusion(absent)),
+		test.WithValidAssignment(nonInclusion(1<<14)),
+		test.WithInvalidAssignment(nonInclusion(key)),
+		test.WithCurves(ecc.BN254),
+	)
+
+	// update of an existing key and insertion of an absent one
+	oldProof := inclusion(key, oldValue)
+	var newValue fr.Element
+	newValue.SetRandom()
+	tree.values[key] = newValue
+	oldNonInclusion := nonInclusion(absent)
+	tree.values[absent] = newValue
+
+	stale := inclusion(key, oldValue)
+	assert.NoError(test.IsSolved(&inclusionCircuit{}, inclusion(key, newValue), ecc.BN254.ScalarField()))
+	assert.NoError(test.IsSolved(&inclusionCircuit{}, inclusion(absent, newValue), ecc.BN254.ScalarField()))
+	assert.Error(test.IsSolved(&inclusionCircuit{}, stale, ecc.BN254.ScalarField()))
+	assert.Error(test.IsSolved(&nonInclusionCircuit{}, nonInclusion(absent), ecc.BN254.ScalarField()))
+
+	// the old proofs only hold against the old roots
+	oldProof.Root = stale.Root
+	assert.Error(test.IsSolved(&inclusionCircuit{}, oldProof, ecc.BN254.ScalarField()))
+	oldNonInclusion.Root = stale.Root
+	assert.Error(test.IsSolved(&nonInclusionCircuit{}, oldNonInclusion, ecc.BN254.ScalarField()))
+}
@@ -90,6 +90,58 @@ func TestIsOnCurve(t *testing.T) {
 
 }
 
+type mustBeConstant struct {
+	curveID twistededwards.ID
+	P       Point
+}
+
+func (circuit *mustBeConstant) Define(api frontend.API) error {
+	params, err := GetCurveParams(circuit.curveID)
+	if err != nil {
+		return err
+	}
+
+	var base Point
+	base.Constant(api, params.Base[0], params.Base[1])
+	AssertIsEqual(api, circuit.P, base)
+
+	return nil
+}
+
+func TestAssertIsEqualConstant(t *testing.T) {
+
+	assert := test.NewAssert(t)
+
+	for _, curve := range curves {
+		var circuit, validWitness, invalidWitness mustBeConstant
+		circuit.curveID = curve
+
+		// get matching snark curve
+		snarkField, err := GetSnarkField(curve)
+		assert.NoError(err)
+		snarkCurve := utils.FieldToCurve(snarkField)
+
+		// get curve params
+		params, err := GetCurveParams(curve)
+		assert.NoError(err)
+
+		// create witness
+		validWitness.P.X = params.Base[0]
+		validWitness.P.Y = params.Base[1]
+
+		invalidWitness.P.X = params.Base[0]
+		invalidWitness.P.Y = params.randomScalar()
+
+		// check circuits.
+		assert.CheckCircuit(&circuit,
+			test.WithValidAssignment(&validWitness),
+			test.WithInvalidAssignment(&invalidWitness),
+			test.WithCurves(snarkCurve))
+
+	}
+
+}
+
 type addCircuit struct {
 	curveID               twistededwards.ID
 	P1, P2                Point
@@ -17,9 +17,26 @@ limitations under the License.
 package twistededwards
 
 import (
+	"math/big"
+
 	"github.com/consensys/gnark/frontend"
 )
 
+// Constant sets p to the point of coordinates (x, y), known at compile time,
+// and returns p. The coordinates are reduced modulo the native field. The point
+// is not checked to be on the curve.
+func (p *Point) Constant(api frontend.API, x, y *big.Int) *Point {
+	p.X = new(big.Int).Mod(x, api.Compiler().Field())
+	p.Y = new(big.Int).Mod(y, api.Compiler().Field())
+	return p
+}
+
+// AssertIsEqual constrains the points p and q to be equal, coordinate-wise.
+func AssertIsEqual(api frontend.API, p, q Point) {
+	api.AssertIsEqual(p.X, q.X)
+	api.AssertIsEqual(p.Y, q.Y)
+}
+
 // neg computes the negative of a point in SNARK coordinates
 func (p *Point) neg(api frontend.API, p1 *Point) *Point {
 	p.X = api.Neg(p1.X)
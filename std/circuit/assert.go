@@ -0,0 +1,15 @@
+// Package circuit provides generic helpers to gate constraints of a circuit.
+//
+// This is useful for circuits with a row structure, such as zkVM circuits,
+// where a constraint only applies to the rows for which a selector is set.
+package circuit
+
+import "github.com/consensys/gnark/frontend"
+
+// AssertIf defines a constraint which enforces a == b when cond == 1, and is
+// always satisfied when cond == 0. cond is assumed to be boolean; this is not
+// enforced.
+func AssertIf(api frontend.API, cond, a, b frontend.Variable) {
+	// cond * (a - b) == 0
+	api.AssertIsEqual(api.Mul(cond, api.Sub(a, b)), 0)
+}
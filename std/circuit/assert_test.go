@@ -0,0 +1,30 @@
+package circuit_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/circuit"
+	"github.com/consensys/gnark/test"
+)
+
+type assertIfCircuit struct {
+	Cond, A, B frontend.Variable
+}
+
+func (c *assertIfCircuit) Define(api frontend.API) error {
+	circuit.AssertIf(api, c.Cond, c.A, c.B)
+	return nil
+}
+
+func TestAssertIf(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// cond == 0: no constraint
+	assert.ProverSucceeded(&assertIfCircuit{}, &assertIfCircuit{Cond: 0, A: 3, B: 4})
+	assert.ProverSucceeded(&assertIfCircuit{}, &assertIfCircuit{Cond: 0, A: 3, B: 3})
+
+	// cond == 1: equality enforced
+	assert.ProverSucceeded(&assertIfCircuit{}, &assertIfCircuit{Cond: 1, A: 5, B: 5})
+	assert.ProverFailed(&assertIfCircuit{}, &assertIfCircuit{Cond: 1, A: 5, B: 6})
+}
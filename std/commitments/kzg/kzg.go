@@ -0,0 +1,22 @@
+// Package kzg provides the curve-independent building blocks of the in-circuit
+// verification of KZG polynomial commitments.
+//
+// The opening proofs of commitments are verified by the curve-specific packages
+// [github.com/consensys/gnark/std/commitments/kzg_bls12377] and
+// [github.com/consensys/gnark/std/commitments/kzg_bls24315]. For small
+// polynomials, it is cheaper to provide the coefficients in-circuit and
+// evaluate them directly with AssertEval.
+package kzg
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/polynomial"
+)
+
+// AssertEval asserts that the polynomial of coefficients coeffs, constant
+// term first, evaluates to value at point. The polynomial is evaluated with
+// Horner's rule, which costs one multiplication per coefficient. The empty
+// polynomial is the zero polynomial.
+func AssertEval(api frontend.API, coeffs []frontend.Variable, point, value frontend.Variable) {
+	api.AssertIsEqual(polynomial.Polynomial(coeffs).Eval(api, point), value)
+}
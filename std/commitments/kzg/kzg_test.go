@@ -0,0 +1,33 @@
+package kzg
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type evalCircuit struct {
+	Coeffs       [4]frontend.Variable
+	Point, Value frontend.Variable
+}
+
+func (c *evalCircuit) Define(api frontend.API) error {
+	AssertEval(api, c.Coeffs[:], c.Point, c.Value)
+	return nil
+}
+
+func TestAssertEval(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// p(X) = 1 + 2X + 3X² + 4X³, p(2) = 49
+	assert.CheckCircuit(&evalCircuit{},
+		test.WithValidAssignment(&evalCircuit{Coeffs: [4]frontend.Variable{1, 2, 3, 4}, Point: 2, Value: 49}),
+		test.WithValidAssignment(&evalCircuit{Coeffs: [4]frontend.Variable{1, 2, 3, 4}, Point: 0, Value: 1}),
+		test.WithValidAssignment(&evalCircuit{Coeffs: [4]frontend.Variable{1, 2, 3, 4}, Point: -1, Value: -2}),
+		test.WithInvalidAssignment(&evalCircuit{Coeffs: [4]frontend.Variable{1, 2, 3, 4}, Point: 2, Value: 48}),
+		test.WithInvalidAssignment(&evalCircuit{Coeffs: [4]frontend.Variable{4, 3, 2, 1}, Point: 2, Value: 49}),
+		test.WithCurves(ecc.BN254),
+	)
+}
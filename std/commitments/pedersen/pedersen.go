@@ -0,0 +1,49 @@
+// Package pedersen provides ZKP-circuit functions to open Pedersen commitments
+// over a twisted Edwards curve.
+package pedersen
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+)
+
+// VerifyOpening asserts that commitment is the Pedersen commitment to value
+// with the given randomness, that is
+//
+//	commitment = [value]G + [randomness]H.
+//
+// G and H must be points of the prime order subgroup of the curve, with an
+// unknown discrete logarithm relation for the commitment to be binding.
+func VerifyOpening(curve twistededwards.Curve, commitment twistededwards.Point, value, randomness frontend.Variable, G, H twistededwards.Point) {
+	api := curve.API()
+	c := curve.DoubleBaseScalarMul(G, H, value, randomness)
+	api.AssertIsEqual(c.X, commitment.X)
+	api.AssertIsEqual(c.Y, commitment.Y)
+}
+
+// CommitVector returns the Pedersen vector commitment to values with the given
+// randomness, that is
+//
+//	[values[0]]bases[0] + … + [values[n-1]]bases[n-1] + [randomness]H.
+//
+// It panics if values and bases don't have the same length. The bases and H
+// must be points of the prime order subgroup of the curve, with no known
+// discrete logarithm relation between them for the commitment to be binding.
+func CommitVector(curve twistededwards.Curve, values []frontend.Variable, bases []twistededwards.Point, randomness frontend.Variable, H twistededwards.Point) twistededwards.Point {
+	if len(values) != len(bases) {
+		panic("values and bases must have the same length")
+	}
+	// the scalar multiplications are paired, starting with the blinding term
+	var res twistededwards.Point
+	i := 0
+	if len(values)%2 == 0 {
+		res = curve.ScalarMul(H, randomness)
+	} else {
+		res = curve.DoubleBaseScalarMul(H, bases[0], randomness, values[0])
+		i = 1
+	}
+	for ; i < len(values); i += 2 {
+		res = curve.Add(res, curve.DoubleBaseScalarMul(bases[i], bases[i+1], values[i], values[i+1]))
+	}
+	return res
+}
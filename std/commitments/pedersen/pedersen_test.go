@@ -0,0 +1,143 @@
+package pedersen
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	edwardsbn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/test"
+)
+
+type openingCircuit struct {
+	Commitment        twistededwards.Point
+	Value, Randomness frontend.Variable
+	G, H              twistededwards.Point
+}
+
+func (c *openingCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	VerifyOpening(curve, c.Commitment, c.Value, c.Randomness, c.G, c.H)
+	return nil
+}
+
+func toPoint(p *edwardsbn254.PointAffine) twistededwards.Point {
+	return twistededwards.Point{X: p.X, Y: p.Y}
+}
+
+func TestVerifyOpening(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	params := edwardsbn254.GetEdwardsCurve()
+	randomScalar := func() *big.Int {
+		s, err := rand.Int(rand.Reader, &params.Order)
+		assert.NoError(err)
+		return s
+	}
+
+	// native commitment
+	var G, H, vG, rH, commitment edwardsbn254.PointAffine
+	G.Set(&params.Base)
+	H.ScalarMultiplication(&params.Base, randomScalar())
+	value, randomness := randomScalar(), randomScalar()
+	vG.ScalarMultiplication(&G, value)
+	rH.ScalarMultiplication(&H, randomness)
+	commitment.Add(&vG, &rH)
+
+	valid := openingCircuit{
+		Commitment: toPoint(&commitment),
+		Value:      value,
+		Randomness: randomness,
+		G:          toPoint(&G),
+		H:          toPoint(&H),
+	}
+	wrongValue := valid
+	wrongValue.Value = new(big.Int).Add(value, big.NewInt(1))
+	swapped := valid
+	swapped.G, swapped.H = valid.H, valid.G
+
+	assert.CheckCircuit(&openingCircuit{},
+		test.WithValidAssignment(&valid),
+		test.WithInvalidAssignment(&wrongValue),
+		test.WithInvalidAssignment(&swapped),
+		test.WithCurves(ecc.BN254),
+	)
+}
+
+type vectorCommitmentCircuit struct {
+	Commitment twistededwards.Point
+	Values     []frontend.Variable
+	Randomness frontend.Variable
+	Bases      []twistededwards.Point
+	H          twistededwards.Point
+}
+
+func (c *vectorCommitmentCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	commitment := CommitVector(curve, c.Values, c.Bases, c.Randomness, c.H)
+	twistededwards.AssertIsEqual(api, commitment, c.Commitment)
+	return nil
+}
+
+func TestCommitVector(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	params := edwardsbn254.GetEdwardsCurve()
+	randomScalar := func() *big.Int {
+		s, err := rand.Int(rand.Reader, &params.Order)
+		assert.NoError(err)
+		return s
+	}
+
+	for _, n := range []int{0, 1, 4, 5} {
+		// native commitment
+		var H, commitment edwardsbn254.PointAffine
+		H.ScalarMultiplication(&params.Base, randomScalar())
+		randomness := randomScalar()
+		commitment.ScalarMultiplication(&H, randomness)
+		valid := vectorCommitmentCircuit{
+			Values:     make([]frontend.Variable, n),
+			Randomness: randomness,
+			Bases:      make([]twistededwards.Point, n),
+			H:          toPoint(&H),
+		}
+		for i := 0; i < n; i++ {
+			var base, term edwardsbn254.PointAffine
+			base.ScalarMultiplication(&params.Base, randomScalar())
+			value := randomScalar()
+			term.ScalarMultiplication(&base, value)
+			commitment.Add(&commitment, &term)
+			valid.Values[i] = value
+			valid.Bases[i] = toPoint(&base)
+		}
+		valid.Commitment = toPoint(&commitment)
+
+		wrongRandomness := valid
+		wrongRandomness.Randomness = new(big.Int).Add(randomness, big.NewInt(1))
+		opts := []test.TestingOption{
+			test.WithValidAssignment(&valid),
+			test.WithInvalidAssignment(&wrongRandomness),
+			test.WithCurves(ecc.BN254),
+		}
+		if n > 1 {
+			swapped := valid
+			swapped.Values = append([]frontend.Variable{}, valid.Values...)
+			swapped.Values[0], swapped.Values[1] = valid.Values[1], valid.Values[0]
+			opts = append(opts, test.WithInvalidAssignment(&swapped))
+		}
+		assert.CheckCircuit(&vectorCommitmentCircuit{
+			Values: make([]frontend.Variable, n),
+			Bases:  make([]twistededwards.Point, n),
+		}, opts...)
+	}
+}
@@ -0,0 +1,194 @@
+// Package aes implements the AES-128 block cipher in-circuit.
+//
+// The state is handled as bytes, stored column by column in [uints.U32]
+// values, so that AddRoundKey and MixColumns are performed with the bytewise
+// XOR of the [uints] package. The S-box and the multiplication by x in
+// GF(2⁸) are performed with lookups in tables of 256 entries, which also
+// constrains the looked up values to be bytes.
+//
+// Only the encryption of a single block is implemented. Modes of operation
+// are left to the caller.
+package aes
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/kvstore"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// BlockSize is the AES block size in bytes.
+const BlockSize = 16
+
+const nbRounds = 10
+
+// sbox is the AES S-box, xtime the multiplication by x in GF(2⁸) modulo
+// x⁸+x⁴+x³+x+1. Both are computed at initialization.
+var sbox, xtime [256]byte
+
+func init() {
+	mul := func(a, b byte) byte {
+		var res byte
+		for ; b != 0; b >>= 1 {
+			if b&1 == 1 {
+				res ^= a
+			}
+			a = a<<1 ^ (a>>7)*0x1b
+		}
+		return res
+	}
+	for i := 0; i < 256; i++ {
+		a := byte(i)
+		xtime[i] = mul(a, 2)
+
+		// the inverse of a is a²⁵⁴ (and 0 is mapped to 0)
+		inv := byte(1)
+		for j := 0; j < 254; j++ {
+			inv = mul(inv, a)
+		}
+		if a == 0 {
+			inv = 0
+		}
+		s := inv
+		for j := 1; j < 5; j++ {
+			s ^= inv<<j | inv>>(8-j)
+		}
+		sbox[i] = s ^ 0x63
+	}
+}
+
+type ctxTablesKey struct{}
+
+// tables holds the lookup tables, shared by all the blocks encrypted in a
+// circuit.
+type tables struct {
+	sbox, xtime *logderivlookup.Table
+}
+
+func newTables(api frontend.API) *tables {
+	kv, ok := api.Compiler().(kvstore.Store)
+	if !ok {
+		panic("builder should implement key-value store")
+	}
+	if t, ok := kv.GetKeyValue(ctxTablesKey{}).(*tables); ok {
+		return t
+	}
+	t := &tables{
+		sbox:  logderivlookup.New(api),
+		xtime: logderivlookup.New(api),
+	}
+	for i := 0; i < 256; i++ {
+		t.sbox.Insert(sbox[i])
+		t.xtime.Insert(xtime[i])
+	}
+	kv.SetKeyValue(ctxTablesKey{}, t)
+	return t
+}
+
+type cipher struct {
+	uapi *uints.BinaryField[uints.U32]
+	tbl  *tables
+}
+
+// EncryptBlock returns the AES-128 encryption of block under key. The inputs
+// are constrained to be bytes, and so are the returned values.
+func EncryptBlock(api frontend.API, key, block [BlockSize]frontend.Variable) [BlockSize]frontend.Variable {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		panic(fmt.Errorf("new uints api: %w", err))
+	}
+	c := cipher{uapi: uapi, tbl: newTables(api)}
+
+	var k, state [4]uints.U32
+	for i := 0; i < BlockSize; i++ {
+		k[i/4][i%4] = uapi.ByteValueOf(key[i])
+		state[i/4][i%4] = uapi.ByteValueOf(block[i])
+	}
+	roundKeys := c.expandKey(k)
+
+	c.addRoundKey(&state, roundKeys[0:4])
+	for r := 1; r < nbRounds; r++ {
+		c.subBytes(&state)
+		shiftRows(&state)
+		c.mixColumns(&state)
+		c.addRoundKey(&state, roundKeys[4*r:4*r+4])
+	}
+	c.subBytes(&state)
+	shiftRows(&state)
+	c.addRoundKey(&state, roundKeys[4*nbRounds:])
+
+	var res [BlockSize]frontend.Variable
+	for i := 0; i < BlockSize; i++ {
+		res[i] = state[i/4][i%4].Val
+	}
+	return res
+}
+
+// expandKey returns the 4·(nbRounds+1) words of the AES-128 key schedule.
+func (c *cipher) expandKey(key [4]uints.U32) []uints.U32 {
+	w := make([]uints.U32, 4*(nbRounds+1))
+	copy(w, key[:])
+	rcon := byte(1)
+	for i := 4; i < len(w); i++ {
+		tmp := w[i-1]
+		if i%4 == 0 {
+			// RotWord, SubWord and the round constant
+			tmp = c.subWord(uints.U32{tmp[1], tmp[2], tmp[3], tmp[0]})
+			tmp = c.uapi.Xor(tmp, uints.U32{uints.NewU8(rcon), uints.NewU8(0), uints.NewU8(0), uints.NewU8(0)})
+			rcon = xtime[rcon]
+		}
+		w[i] = c.uapi.Xor(w[i-4], tmp)
+	}
+	return w
+}
+
+func (c *cipher) subWord(a uints.U32) uints.U32 {
+	vals := c.tbl.sbox.Lookup(a[0].Val, a[1].Val, a[2].Val, a[3].Val)
+	return uints.U32{{Val: vals[0]}, {Val: vals[1]}, {Val: vals[2]}, {Val: vals[3]}}
+}
+
+func (c *cipher) xtimeWord(a uints.U32) uints.U32 {
+	vals := c.tbl.xtime.Lookup(a[0].Val, a[1].Val, a[2].Val, a[3].Val)
+	return uints.U32{{Val: vals[0]}, {Val: vals[1]}, {Val: vals[2]}, {Val: vals[3]}}
+}
+
+func (c *cipher) addRoundKey(state *[4]uints.U32, roundKey []uints.U32) {
+	for i := range state {
+		state[i] = c.uapi.Xor(state[i], roundKey[i])
+	}
+}
+
+func (c *cipher) subBytes(state *[4]uints.U32) {
+	for i := range state {
+		state[i] = c.subWord(state[i])
+	}
+}
+
+// shiftRows rotates the row r of the state by r positions to the left. The
+// state is stored column by column, so it only moves bytes around.
+func shiftRows(state *[4]uints.U32) {
+	var res [4]uints.U32
+	for col := 0; col < 4; col++ {
+		for row := 0; row < 4; row++ {
+			res[col][row] = state[(col+row)%4][row]
+		}
+	}
+	*state = res
+}
+
+// mixColumns multiplies each column by the polynomial 3x³+x²+x+2. Writing
+// rot(a) for the column a rotated by one byte, the result is
+//
+//	2·a + 3·rot(a) + rot²(a) + rot³(a) = xtime(a) ⊕ rot(xtime(a)) ⊕ rot(a) ⊕ rot²(a) ⊕ rot³(a).
+func (c *cipher) mixColumns(state *[4]uints.U32) {
+	rot := func(a uints.U32, n int) uints.U32 {
+		return uints.U32{a[n%4], a[(n+1)%4], a[(n+2)%4], a[(n+3)%4]}
+	}
+	for i := range state {
+		a := state[i]
+		b := c.xtimeWord(a)
+		state[i] = c.uapi.Xor(b, rot(b, 1), rot(a, 1), rot(a, 2), rot(a, 3))
+	}
+}
@@ -0,0 +1,49 @@
+package aes
+
+import (
+	"crypto/aes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type encryptCircuit struct {
+	Key, Block, Expected [BlockSize]frontend.Variable
+}
+
+func (c *encryptCircuit) Define(api frontend.API) error {
+	res := EncryptBlock(api, c.Key, c.Block)
+	for i := range res {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestEncryptBlock(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var key, block, expected [BlockSize]byte
+	_, err := rand.Read(key[:])
+	assert.NoError(err)
+	_, err = rand.Read(block[:])
+	assert.NoError(err)
+	c, err := aes.NewCipher(key[:])
+	assert.NoError(err)
+	c.Encrypt(expected[:], block[:])
+
+	var witness encryptCircuit
+	for i := 0; i < BlockSize; i++ {
+		witness.Key[i] = key[i]
+		witness.Block[i] = block[i]
+		witness.Expected[i] = expected[i]
+	}
+	err = test.IsSolved(&encryptCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	witness.Expected[0] = expected[0] ^ 1
+	err = test.IsSolved(&encryptCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
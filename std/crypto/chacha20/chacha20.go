@@ -0,0 +1,73 @@
+// Package chacha20 implements the ChaCha20 block function (RFC 8439) in-circuit.
+//
+// The 32-bit words of the state are decomposed into bits, see [bitwords].
+package chacha20
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/internal/bitwords"
+)
+
+// BlockSize is the number of 32-bit words of a keystream block.
+const BlockSize = 16
+
+// sigma is "expand 32-byte k" read as little-endian words.
+var sigma = [4]uint32{0x61707865, 0x3320646e, 0x79622d32, 0x6b206574}
+
+type word = bitwords.Word
+
+type chacha struct {
+	api frontend.API
+}
+
+// Block returns the keystream block of ChaCha20 for key, nonce and counter, as
+// 16 words of 32 bits. The key and the nonce are given as little-endian words,
+// as in RFC 8439: serializing the returned words in little-endian gives the 64
+// bytes of the keystream. All the inputs are constrained to be on 32 bits.
+func Block(api frontend.API, key [8]frontend.Variable, nonce [3]frontend.Variable, counter frontend.Variable) [BlockSize]frontend.Variable {
+	c := chacha{api: api}
+
+	var initial [BlockSize]word
+	for i := range sigma {
+		initial[i] = bitwords.Constant(sigma[i])
+	}
+	for i := range key {
+		initial[4+i] = bitwords.FromValue(api, key[i])
+	}
+	initial[12] = bitwords.FromValue(api, counter)
+	for i := range nonce {
+		initial[13+i] = bitwords.FromValue(api, nonce[i])
+	}
+
+	x := initial
+	for i := 0; i < 10; i++ {
+		// column rounds
+		c.quarterRound(&x, 0, 4, 8, 12)
+		c.quarterRound(&x, 1, 5, 9, 13)
+		c.quarterRound(&x, 2, 6, 10, 14)
+		c.quarterRound(&x, 3, 7, 11, 15)
+		// diagonal rounds
+		c.quarterRound(&x, 0, 5, 10, 15)
+		c.quarterRound(&x, 1, 6, 11, 12)
+		c.quarterRound(&x, 2, 7, 8, 13)
+		c.quarterRound(&x, 3, 4, 9, 14)
+	}
+
+	var res [BlockSize]frontend.Variable
+	for i := range res {
+		res[i] = bitwords.Value(api, bitwords.Add(api, x[i], initial[i]))
+	}
+	return res
+}
+
+func (c *chacha) quarterRound(x *[BlockSize]word, a, b, cc, d int) {
+	api := c.api
+	x[a] = bitwords.Add(api, x[a], x[b])
+	x[d] = bitwords.RotL(bitwords.Xor(api, x[d], x[a]), 16)
+	x[cc] = bitwords.Add(api, x[cc], x[d])
+	x[b] = bitwords.RotL(bitwords.Xor(api, x[b], x[cc]), 12)
+	x[a] = bitwords.Add(api, x[a], x[b])
+	x[d] = bitwords.RotL(bitwords.Xor(api, x[d], x[a]), 8)
+	x[cc] = bitwords.Add(api, x[cc], x[d])
+	x[b] = bitwords.RotL(bitwords.Xor(api, x[b], x[cc]), 7)
+}
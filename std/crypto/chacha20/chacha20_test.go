@@ -0,0 +1,63 @@
+package chacha20
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/chacha20"
+)
+
+type blockCircuit struct {
+	Key      [8]frontend.Variable
+	Nonce    [3]frontend.Variable
+	Counter  frontend.Variable
+	Expected [BlockSize]frontend.Variable
+}
+
+func (c *blockCircuit) Define(api frontend.API) error {
+	res := Block(api, c.Key, c.Nonce, c.Counter)
+	for i := range res {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestBlock(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var key [chacha20.KeySize]byte
+	var nonce [chacha20.NonceSize]byte
+	_, err := rand.Read(key[:])
+	assert.NoError(err)
+	_, err = rand.Read(nonce[:])
+	assert.NoError(err)
+	const counter = 7
+
+	c, err := chacha20.NewUnauthenticatedCipher(key[:], nonce[:])
+	assert.NoError(err)
+	c.SetCounter(counter)
+	var keystream [4 * BlockSize]byte
+	c.XORKeyStream(keystream[:], keystream[:])
+
+	var witness blockCircuit
+	for i := range witness.Key {
+		witness.Key[i] = binary.LittleEndian.Uint32(key[4*i:])
+	}
+	for i := range witness.Nonce {
+		witness.Nonce[i] = binary.LittleEndian.Uint32(nonce[4*i:])
+	}
+	witness.Counter = counter
+	for i := range witness.Expected {
+		witness.Expected[i] = binary.LittleEndian.Uint32(keystream[4*i:])
+	}
+	err = test.IsSolved(&blockCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	witness.Expected[0] = binary.LittleEndian.Uint32(keystream[:]) ^ 1
+	err = test.IsSolved(&blockCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,90 @@
+// Package crc32 implements the 32-bit cyclic redundancy check in-circuit.
+//
+// The checksum is computed bytewise with a table of 256 entries, as in the
+// standard library package hash/crc32. The table is stored as four lookup
+// tables, one per byte of the entries, and the XORs are performed with the
+// bytewise XOR of the [uints] package.
+package crc32
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/kvstore"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// The usual polynomials, in reversed notation.
+const (
+	IEEE       = 0xedb88320
+	Castagnoli = 0x82f63b78
+	Koopman    = 0xeb31d82e
+)
+
+type ctxTableKey struct{ poly uint32 }
+
+// table holds the entries of the CRC table of a polynomial, split bytewise.
+type table [4]*logderivlookup.Table
+
+func newTable(api frontend.API, poly uint32) *table {
+	kv, ok := api.Compiler().(kvstore.Store)
+	if !ok {
+		panic("builder should implement key-value store")
+	}
+	if t, ok := kv.GetKeyValue(ctxTableKey{poly: poly}).(*table); ok {
+		return t
+	}
+	var t table
+	for j := range t {
+		t[j] = logderivlookup.New(api)
+	}
+	for i := 0; i < 256; i++ {
+		crc := uint32(i)
+		for k := 0; k < 8; k++ {
+			if crc&1 == 1 {
+				crc = crc>>1 ^ poly
+			} else {
+				crc >>= 1
+			}
+		}
+		for j := range t {
+			t[j].Insert(crc >> (8 * j) & 0xff)
+		}
+	}
+	kv.SetKeyValue(ctxTableKey{poly: poly}, &t)
+	return &t
+}
+
+// Compute returns the CRC-32 checksum of data with the polynomial poly, given
+// in reversed notation as in hash/crc32 (e.g. IEEE). The elements of data are
+// constrained to be bytes. The result is the same as the one of
+// crc32.Checksum(data, crc32.MakeTable(poly)).
+func Compute(api frontend.API, data []frontend.Variable, poly uint32) frontend.Variable {
+	if len(data) == 0 {
+		// don't create tables which would never be queried
+		return 0
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		panic(fmt.Errorf("new uints api: %w", err))
+	}
+	tbl := newTable(api, poly)
+
+	// the checksum is stored least significant byte first
+	zero := uints.NewU8(0)
+	crc := uints.NewU32(0xffffffff)
+	for i := range data {
+		b := uapi.ByteValueOf(data[i])
+		x := uapi.Xor(crc, uints.U32{b, zero, zero, zero})
+		var entry uints.U32
+		for j := range entry {
+			entry[j] = uints.U8{Val: tbl[j].Lookup(x[0].Val)[0]}
+		}
+		crc = uapi.Xor(entry, uints.U32{x[1], x[2], x[3], zero})
+	}
+
+	// the final XOR with 0xffffffff is a subtraction on 32 bits
+	return api.Sub(new(big.Int).SetUint64(0xffffffff), uapi.ToValue(crc))
+}
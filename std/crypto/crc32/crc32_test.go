@@ -0,0 +1,47 @@
+package crc32
+
+import (
+	"crypto/rand"
+	"hash/crc32"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type crcCircuit struct {
+	Data     []frontend.Variable
+	Expected frontend.Variable
+	poly     uint32
+}
+
+func (c *crcCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(Compute(api, c.Data, c.poly), c.Expected)
+	return nil
+}
+
+func TestCompute(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, poly := range []uint32{IEEE, Castagnoli, Koopman} {
+		for _, size := range []int{0, 1, 37} {
+			data := make([]byte, size)
+			_, err := rand.Read(data)
+			assert.NoError(err)
+			expected := crc32.Checksum(data, crc32.MakeTable(poly))
+
+			witness := crcCircuit{Data: make([]frontend.Variable, size), Expected: expected}
+			for i := range data {
+				witness.Data[i] = data[i]
+			}
+			circuit := crcCircuit{Data: make([]frontend.Variable, size), poly: poly}
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err, "poly %x, size %d", poly, size)
+
+			witness.Expected = expected ^ 1
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.Error(err, "poly %x, size %d", poly, size)
+		}
+	}
+}
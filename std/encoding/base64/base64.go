@@ -0,0 +1,110 @@
+// Package base64 implements the decoding of Base64 (RFC 4648) in-circuit.
+//
+// Each symbol is mapped to its 6-bit value with a lookup in a table of 256
+// entries, in which the characters out of the alphabet are mapped to 64. The
+// values are then decomposed on 6 bits, which rejects the invalid characters,
+// and the bits are repacked into bytes.
+package base64
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/kvstore"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+const (
+	stdAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	urlAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+	padding = '='
+	invalid = 64
+)
+
+type ctxTableKey struct{ alphabet string }
+
+func newTable(api frontend.API, alphabet string) *logderivlookup.Table {
+	kv, ok := api.Compiler().(kvstore.Store)
+	if !ok {
+		panic("builder should implement key-value store")
+	}
+	if t, ok := kv.GetKeyValue(ctxTableKey{alphabet: alphabet}).(*logderivlookup.Table); ok {
+		return t
+	}
+	var values [256]int
+	for i := range values {
+		values[i] = invalid
+	}
+	for i := 0; i < len(alphabet); i++ {
+		values[alphabet[i]] = i
+	}
+	t := logderivlookup.New(api)
+	for i := range values {
+		t.Insert(values[i])
+	}
+	kv.SetKeyValue(ctxTableKey{alphabet: alphabet}, t)
+	return t
+}
+
+// Decode returns the n bytes encoded in Base64 with the standard alphabet in
+// encoded, as base64.StdEncoding or base64.RawStdEncoding do. The elements of
+// encoded are the ASCII codes of the characters. They are either the
+// ⌈4n/3⌉ significant characters, or these followed by the padding characters
+// '=' up to a multiple of 4 characters. Decode panics if len(encoded) is
+// neither.
+//
+// The characters out of the alphabet are rejected, and so are the non-zero
+// unused bits of the last significant character, as in the strict mode of
+// encoding/base64, so that the encoding of a byte string is unique.
+func Decode(api frontend.API, encoded []frontend.Variable, n int) []frontend.Variable {
+	return decode(api, stdAlphabet, encoded, n)
+}
+
+// DecodeURL returns the n bytes encoded in Base64 with the URL and filename
+// safe alphabet in encoded, as base64.URLEncoding or base64.RawURLEncoding do.
+// It is otherwise the same as Decode.
+func DecodeURL(api frontend.API, encoded []frontend.Variable, n int) []frontend.Variable {
+	return decode(api, urlAlphabet, encoded, n)
+}
+
+func decode(api frontend.API, alphabet string, encoded []frontend.Variable, n int) []frontend.Variable {
+	if n < 0 {
+		panic("negative decoded length")
+	}
+	nbSymbols := (4*n + 2) / 3
+	if len(encoded) != nbSymbols && len(encoded) != 4*((n+2)/3) {
+		panic(fmt.Sprintf("%d characters can't encode %d bytes", len(encoded), n))
+	}
+	if n == 0 {
+		return nil
+	}
+	for _, c := range encoded[nbSymbols:] {
+		api.AssertIsEqual(c, padding)
+	}
+
+	// the bits of the symbols, most significant first
+	tbl := newTable(api, alphabet)
+	values := tbl.Lookup(encoded[:nbSymbols]...)
+	allBits := make([]frontend.Variable, 0, 6*nbSymbols)
+	for i := range values {
+		b := bits.ToBinary(api, values[i], bits.WithNbDigits(6))
+		for j := len(b) - 1; j >= 0; j-- {
+			allBits = append(allBits, b[j])
+		}
+	}
+
+	res := make([]frontend.Variable, n)
+	byteBits := make([]frontend.Variable, 8)
+	for i := range res {
+		for j := range byteBits {
+			byteBits[j] = allBits[8*i+7-j]
+		}
+		res[i] = bits.FromBinary(api, byteBits, bits.WithUnconstrainedInputs())
+	}
+	for _, b := range allBits[8*n:] {
+		api.AssertIsEqual(b, 0)
+	}
+	return res
+}
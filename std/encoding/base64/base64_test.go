@@ -0,0 +1,104 @@
+package base64
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type decodeCircuit struct {
+	Encoded  []frontend.Variable
+	Expected []frontend.Variable
+	url      bool
+}
+
+func (c *decodeCircuit) Define(api frontend.API) error {
+	decodeFn := Decode
+	if c.url {
+		decodeFn = DecodeURL
+	}
+	res := decodeFn(api, c.Encoded, len(c.Expected))
+	for i := range res {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func newDecodeAssignment(encoded string, decoded []byte) *decodeCircuit {
+	c := &decodeCircuit{
+		Encoded:  make([]frontend.Variable, len(encoded)),
+		Expected: make([]frontend.Variable, len(decoded)),
+	}
+	for i := range encoded {
+		c.Encoded[i] = encoded[i]
+	}
+	for i := range decoded {
+		c.Expected[i] = decoded[i]
+	}
+	return c
+}
+
+func TestDecode(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	encodings := []struct {
+		name string
+		enc  *base64.Encoding
+		url  bool
+	}{
+		{"std", base64.StdEncoding, false},
+		{"rawstd", base64.RawStdEncoding, false},
+		{"url", base64.URLEncoding, true},
+		{"rawurl", base64.RawURLEncoding, true},
+	}
+	for _, e := range encodings {
+		for n := 0; n < 8; n++ {
+			e, n := e, n
+			assert.Run(func(assert *test.Assert) {
+				decoded := make([]byte, n)
+				_, err := rand.Read(decoded)
+				assert.NoError(err)
+				encoded := e.enc.EncodeToString(decoded)
+
+				witness := newDecodeAssignment(encoded, decoded)
+				circuit := newDecodeAssignment(encoded, decoded)
+				circuit.url = e.url
+				err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+				assert.NoError(err)
+
+				if n > 0 {
+					witness.Expected[0] = decoded[0] ^ 1
+					err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+					assert.Error(err)
+				}
+			}, e.name, fmt.Sprintf("n=%d", n))
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	check := func(encoded string, decoded []byte, url bool) error {
+		circuit := newDecodeAssignment(encoded, decoded)
+		circuit.url = url
+		return test.IsSolved(circuit, newDecodeAssignment(encoded, decoded), ecc.BN254.ScalarField())
+	}
+	assert.NoError(check("+/8=", []byte{0xfb, 0xff}, false))
+	assert.NoError(check("-_8", []byte{0xfb, 0xff}, true))
+
+	// characters of the other alphabet
+	assert.Error(check("-_8=", []byte{0xfb, 0xff}, false))
+	assert.Error(check("+/8", []byte{0xfb, 0xff}, true))
+	// character out of both alphabets
+	assert.Error(check("*/8=", []byte{0xfb, 0xff}, false))
+	// non-zero unused bits
+	assert.Error(check("+/9=", []byte{0xfb, 0xff}, false))
+	// invalid padding
+	assert.Error(check("+/8A", []byte{0xfb, 0xff}, false))
+}
@@ -0,0 +1,62 @@
+// Package ethereum implements gadgets for Ethereum-specific primitives.
+package ethereum
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/sha3"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// AddressLen is the length in bytes of an Ethereum address.
+const AddressLen = 20
+
+// AddressFromPubKey returns the Ethereum address of the secp256k1 public key
+// pub, that is the last [AddressLen] bytes of the Keccak-256 hash of the
+// uncompressed encoding X || Y of pub, without the 0x04 prefix. The address is
+// returned in big-endian order, one byte per variable.
+//
+// The coordinates of pub are reduced to their canonical representation, but pub
+// is not checked to be on the curve.
+func AddressFromPubKey(api frontend.API, pub *sw_emulated.AffinePoint[emulated.Secp256k1Fp]) []frontend.Variable {
+	fpField, err := emulated.NewField[emulated.Secp256k1Fp](api)
+	if err != nil {
+		panic(fmt.Sprintf("new field: %v", err))
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		panic(fmt.Sprintf("new uints: %v", err))
+	}
+	h, err := sha3.NewLegacyKeccak256(api)
+	if err != nil {
+		panic(fmt.Sprintf("new keccak: %v", err))
+	}
+
+	h.Write(coordinateBytes(api, uapi, fpField, &pub.X))
+	h.Write(coordinateBytes(api, uapi, fpField, &pub.Y))
+	digest := h.Sum()
+
+	res := make([]frontend.Variable, AddressLen)
+	for i := range res {
+		res[i] = digest[len(digest)-AddressLen+i].Val
+	}
+	return res
+}
+
+// coordinateBytes returns the 32 bytes big-endian encoding of the canonical
+// representative of a.
+func coordinateBytes(api frontend.API, uapi *uints.BinaryField[uints.U64], fpField *emulated.Field[emulated.Secp256k1Fp], a *emulated.Element[emulated.Secp256k1Fp]) []uints.U8 {
+	a = fpField.Reduce(a)
+	fpField.AssertIsInRange(a)
+	bits := fpField.ToBits(a)
+	nbBytes := len(bits) / 8
+	res := make([]uints.U8, nbBytes)
+	for i := range res {
+		lsb := 8 * (nbBytes - 1 - i)
+		res[i] = uapi.ByteValueOf(api.FromBinary(bits[lsb : lsb+8]...))
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+package ethereum
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/sha3"
+)
+
+type addressCircuit struct {
+	PubKey   sw_emulated.AffinePoint[emulated.Secp256k1Fp]
+	Expected [AddressLen]frontend.Variable
+}
+
+func (c *addressCircuit) Define(api frontend.API) error {
+	res := AddressFromPubKey(api, &c.PubKey)
+	for i := range res {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func addressAssignment(pub *secp256k1.G1Affine, address []byte) *addressCircuit {
+	var res addressCircuit
+	res.PubKey = sw_emulated.AffinePoint[emulated.Secp256k1Fp]{
+		X: emulated.ValueOf[emulated.Secp256k1Fp](pub.X),
+		Y: emulated.ValueOf[emulated.Secp256k1Fp](pub.Y),
+	}
+	for i := range res.Expected {
+		res.Expected[i] = address[i]
+	}
+	return &res
+}
+
+func TestAddressFromPubKey(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// the public key of the secret key 1 is the generator of secp256k1.
+	_, g := secp256k1.Generators()
+	address, err := hex.DecodeString("7e5f4552091a69125d5dfcb7b8c2659029395bdf")
+	assert.NoError(err)
+
+	err = test.IsSolved(&addressCircuit{}, addressAssignment(&g, address), ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	address[0] ^= 1
+	err = test.IsSolved(&addressCircuit{}, addressAssignment(&g, address), ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestAddressFromRandomPubKey(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	sk, err := rand.Int(rand.Reader, fr.Modulus())
+	assert.NoError(err)
+	_, g := secp256k1.Generators()
+	var pub secp256k1.G1Affine
+	pub.ScalarMultiplication(&g, sk)
+
+	h := sha3.NewLegacyKeccak256()
+	x, y := pub.X.Bytes(), pub.Y.Bytes()
+	h.Write(x[:])
+	h.Write(y[:])
+	address := h.Sum(nil)[32-AddressLen:]
+
+	err = test.IsSolved(&addressCircuit{}, addressAssignment(&pub, address), ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
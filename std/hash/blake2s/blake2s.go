@@ -0,0 +1,157 @@
+// Package blake2s implements the BLAKE2s-256 hash (RFC 7693) in-circuit.
+//
+// The hash is unkeyed, with the default parameter block: a digest of 32 bytes,
+// no salt and no personalization, as blake2s.New256(nil) in
+// golang.org/x/crypto/blake2s.
+//
+// The 32-bit words of the state are decomposed into bits, see [bitwords].
+package blake2s
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/internal/bitwords"
+	fbits "github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+const (
+	// Size is the size of a BLAKE2s-256 digest in bytes.
+	Size = 32
+	// BlockSize is the size of a block of the compression function in bytes.
+	BlockSize = 64
+)
+
+var iv = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a, 0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var sigma = [10][16]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+type word = bitwords.Word
+
+type digest struct {
+	api frontend.API
+	in  []uints.U8
+}
+
+// New returns a BLAKE2s-256 hasher. The written bytes are constrained to be
+// bytes when the digest is computed.
+func New(api frontend.API) (hash.BinaryHasher, error) {
+	return &digest{api: api}, nil
+}
+
+func (d *digest) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+func (d *digest) Sum() []uints.U8 {
+	var h [8]word
+	for i := range h {
+		h[i] = bitwords.Constant(iv[i])
+	}
+	// parameter block: digest length, no key, fanout and depth of 1
+	h[0] = bitwords.Constant(iv[0] ^ 0x01010000 ^ Size)
+
+	// all the blocks are full but the last one, which is padded with zeroes.
+	// The empty message is hashed as a single block of zeroes.
+	nbBlocks := (len(d.in) + BlockSize - 1) / BlockSize
+	if nbBlocks == 0 {
+		nbBlocks = 1
+	}
+	for i := 0; i < nbBlocks; i++ {
+		var m [16]word
+		for j := range m {
+			m[j] = d.loadWord(i*BlockSize + 4*j)
+		}
+		counter := uint64(BlockSize * (i + 1))
+		final := i == nbBlocks-1
+		if final {
+			counter = uint64(len(d.in))
+		}
+		d.compress(&h, &m, counter, final)
+	}
+
+	res := make([]uints.U8, 0, Size)
+	for i := range h {
+		for j := 0; j < 4; j++ {
+			res = append(res, uints.U8{Val: fbits.FromBinary(d.api, h[i][8*j:8*j+8], fbits.WithUnconstrainedInputs())})
+		}
+	}
+	return res
+}
+
+func (d *digest) Reset() {
+	d.in = nil
+}
+
+func (d *digest) Size() int { return Size }
+
+// loadWord returns the little-endian word of the input at offset, completed
+// with zeroes past the end of the input.
+func (d *digest) loadWord(offset int) word {
+	var w word
+	for i := 0; i < 4; i++ {
+		if offset+i >= len(d.in) {
+			for j := 8 * i; j < len(w); j++ {
+				w[j] = 0
+			}
+			break
+		}
+		copy(w[8*i:], fbits.ToBinary(d.api, d.in[offset+i].Val, fbits.WithNbDigits(8)))
+	}
+	return w
+}
+
+func (d *digest) compress(h *[8]word, m *[16]word, counter uint64, final bool) {
+	var v [16]word
+	copy(v[:], h[:])
+	for i := 0; i < 8; i++ {
+		v[8+i] = bitwords.Constant(iv[i])
+	}
+	v[12] = bitwords.Constant(iv[4] ^ uint32(counter))
+	v[13] = bitwords.Constant(iv[5] ^ uint32(counter>>32))
+	if final {
+		v[14] = bitwords.Constant(^iv[6])
+	}
+
+	for r := 0; r < 10; r++ {
+		s := &sigma[r]
+		d.g(&v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+		d.g(&v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+		d.g(&v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+		d.g(&v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+		d.g(&v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+		d.g(&v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+		d.g(&v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+		d.g(&v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range h {
+		h[i] = bitwords.Xor(d.api, h[i], bitwords.Xor(d.api, v[i], v[i+8]))
+	}
+}
+
+// g is the mixing function of BLAKE2s.
+func (d *digest) g(v *[16]word, a, b, c, dd int, x, y word) {
+	api := d.api
+	v[a] = bitwords.Add(api, v[a], v[b], x)
+	v[dd] = bitwords.RotR(bitwords.Xor(api, v[dd], v[a]), 16)
+	v[c] = bitwords.Add(api, v[c], v[dd])
+	v[b] = bitwords.RotR(bitwords.Xor(api, v[b], v[c]), 12)
+	v[a] = bitwords.Add(api, v[a], v[b], y)
+	v[dd] = bitwords.RotR(bitwords.Xor(api, v[dd], v[a]), 8)
+	v[c] = bitwords.Add(api, v[c], v[dd])
+	v[b] = bitwords.RotR(bitwords.Xor(api, v[b], v[c]), 7)
+}
@@ -0,0 +1,59 @@
+package blake2s
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/blake2s"
+)
+
+type blake2sCircuit struct {
+	In       []uints.U8
+	Expected [Size]uints.U8
+}
+
+func (c *blake2sCircuit) Define(api frontend.API) error {
+	h, err := New(api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	if len(res) != Size {
+		return fmt.Errorf("not %d bytes", Size)
+	}
+	for i := range c.Expected {
+		api.AssertIsEqual(c.Expected[i].Val, res[i].Val)
+	}
+	return nil
+}
+
+func TestBlake2s(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// empty input, partial block, full block and several blocks
+	for _, n := range []int{0, 3, 64, 65, 150} {
+		n := n
+		assert.Run(func(assert *test.Assert) {
+			bts := make([]byte, n)
+			_, err := rand.Read(bts)
+			assert.NoError(err)
+			dgst := blake2s.Sum256(bts)
+
+			witness := blake2sCircuit{In: uints.NewU8Array(bts)}
+			copy(witness.Expected[:], uints.NewU8Array(dgst[:]))
+			circuit := blake2sCircuit{In: make([]uints.U8, n)}
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+
+			witness.Expected[0] = uints.NewU8(dgst[0] ^ 1)
+			err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+			assert.Error(err)
+		}, fmt.Sprintf("len=%d", n))
+	}
+}
@@ -72,3 +72,72 @@ func (h *MiMC) Sum() frontend.Variable {
 	return h.h
 
 }
+
+// UpdateIf absorbs v into the state of the hash when cond is 1, and leaves
+// the state unchanged when cond is 0. cond must be boolean. The data written
+// before is absorbed first, so that the digest returned by Sum is the hash of
+// the data written and of the values absorbed under a true condition, in
+// order.
+func (h *MiMC) UpdateIf(api frontend.API, cond, v frontend.Variable) {
+	h.Sum() // absorb the data written so far
+	updated := Compress(api, *h, v, h.h)
+	h.h = api.Select(cond, updated, h.h)
+}
+
+// Compress returns the Miyaguchi–Preneel compression of the message m under
+// the key k, that is E_k(m) + m + k where E is the MiMC block cipher of the
+// curve h was created for. Sum is the iteration of Compress over the written
+// data, starting from the key 0. The state of h is not modified.
+func Compress(api frontend.API, h MiMC, m, k frontend.Variable) frontend.Variable {
+	h.api = api
+	h.h = k
+	r := encryptFuncs[h.id](h, m)
+	return api.Add(r, m, k)
+}
+
+// HashPadded returns the MiMC digest of the length-prefixed message (realLen,
+// inputs[0], …, inputs[realLen-1]), which matches the native hash of the
+// realLen first inputs preceded by realLen. inputs must have maxLen elements;
+// the ones past realLen are padding and do not affect the digest. realLen is
+// asserted to be in [0, maxLen].
+//
+// The number of constraints only depends on maxLen, so that the circuit does
+// not leak realLen. h is used as a fresh hasher: data written to it before is
+// ignored, and its state is not modified.
+func HashPadded(api frontend.API, h MiMC, inputs []frontend.Variable, realLen frontend.Variable, maxLen int) frontend.Variable {
+	if len(inputs) != maxLen {
+		panic("HashPadded expects exactly maxLen inputs")
+	}
+	h.Reset()
+	h.Write(realLen)
+
+	// active is 1 while i < realLen, and hit accumulates [i == realLen] so that
+	// realLen is guaranteed to be in [0, maxLen].
+	active := frontend.Variable(1)
+	hit := frontend.Variable(0)
+	for i := 0; i < maxLen; i++ {
+		end := api.IsZero(api.Sub(realLen, i))
+		hit = api.Add(hit, end)
+		active = api.Sub(active, end)
+		h.UpdateIf(api, active, inputs[i])
+	}
+	hit = api.Add(hit, api.IsZero(api.Sub(realLen, maxLen)))
+	api.AssertIsEqual(hit, 1)
+
+	return h.Sum()
+}
+
+// VerifyChain asserts that claimedHead is the head of the hash chain starting
+// at start and extended by items, in order, where each link is
+// hᵢ = MiMC(hᵢ₋₁, itemᵢ). When items is empty, the head is start. VerifyChain
+// resets h and writes to it for each link, so that data written to h before
+// the call is ignored. h is passed by value: these writes go to a copy.
+func VerifyChain(api frontend.API, h MiMC, start frontend.Variable, items []frontend.Variable, claimedHead frontend.Variable) {
+	head := start
+	for _, item := range items {
+		h.Reset()
+		h.Write(head, item)
+		head = h.Sum()
+	}
+	api.AssertIsEqual(head, claimedHead)
+}
@@ -17,10 +17,18 @@ limitations under the License.
 package mimc
 
 import (
+	"crypto/rand"
 	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	mimc_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/mimc"
+	mimc_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
+	mimc_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/mimc"
+	mimc_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/mimc"
+	mimc_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	mimc_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/mimc"
+	mimc_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
 	"github.com/consensys/gnark-crypto/hash"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/test"
@@ -93,3 +101,249 @@ func TestMimcAll(t *testing.T) {
 	}
 
 }
+
+var nativeSums = map[ecc.ID]func([]byte) ([]byte, error){
+	ecc.BN254:     mimc_bn254.Sum,
+	ecc.BLS12_381: mimc_bls12381.Sum,
+	ecc.BLS12_377: mimc_bls12377.Sum,
+	ecc.BW6_761:   mimc_bw6761.Sum,
+	ecc.BW6_633:   mimc_bw6633.Sum,
+	ecc.BLS24_315: mimc_bls24315.Sum,
+	ecc.BLS24_317: mimc_bls24317.Sum,
+}
+
+func TestMiMCGadgetMatchesNative(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for curve, nativeSum := range nativeSums {
+		modulus := curve.ScalarField()
+		blockSize := (modulus.BitLen() + 7) / 8
+
+		// the native hash consumes the data as a sequence of big-endian
+		// field elements, each of them taking exactly one block.
+		var witness mimcCircuit
+		msg := make([]byte, 0, len(witness.Data)*blockSize)
+		for i := range witness.Data {
+			v, err := rand.Int(rand.Reader, modulus)
+			assert.NoError(err)
+			witness.Data[i] = v
+			msg = append(msg, v.FillBytes(make([]byte, blockSize))...)
+		}
+		expected, err := nativeSum(msg)
+		assert.NoError(err)
+		witness.ExpectedResult = expected
+
+		assert.NoError(test.IsSolved(&mimcCircuit{}, &witness, modulus), curve.String())
+	}
+}
+
+type compressCircuit struct {
+	M0, M1   frontend.Variable
+	Expected frontend.Variable
+}
+
+func (circuit *compressCircuit) Define(api frontend.API) error {
+	h, err := NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	// hashing two blocks chains two compressions, starting from the key 0.
+	c := Compress(api, h, circuit.M0, 0)
+	c = Compress(api, h, circuit.M1, c)
+	api.AssertIsEqual(c, circuit.Expected)
+	return nil
+}
+
+func TestCompressMatchesNative(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for curve, nativeSum := range nativeSums {
+		modulus := curve.ScalarField()
+		blockSize := (modulus.BitLen() + 7) / 8
+
+		m0, err := rand.Int(rand.Reader, modulus)
+		assert.NoError(err)
+		m1, err := rand.Int(rand.Reader, modulus)
+		assert.NoError(err)
+		msg := append(m0.FillBytes(make([]byte, blockSize)), m1.FillBytes(make([]byte, blockSize))...)
+		expected, err := nativeSum(msg)
+		assert.NoError(err)
+
+		witness := compressCircuit{M0: m0, M1: m1, Expected: expected}
+		assert.NoError(test.IsSolved(&compressCircuit{}, &witness, modulus), curve.String())
+
+		witness.Expected = new(big.Int).Add(new(big.Int).SetBytes(expected), big.NewInt(1))
+		assert.Error(test.IsSolved(&compressCircuit{}, &witness, modulus), curve.String())
+	}
+}
+
+type updateIfCircuit struct {
+	Data     [4]frontend.Variable
+	Flags    [4]frontend.Variable
+	Expected frontend.Variable
+}
+
+func (circuit *updateIfCircuit) Define(api frontend.API) error {
+	h, err := NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	for i := range circuit.Data {
+		h.UpdateIf(api, circuit.Flags[i], circuit.Data[i])
+	}
+	api.AssertIsEqual(h.Sum(), circuit.Expected)
+	return nil
+}
+
+func TestUpdateIf(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	modulus := ecc.BN254.ScalarField()
+	var data [4]*big.Int
+	for i := range data {
+		var err error
+		data[i], err = rand.Int(rand.Reader, modulus)
+		assert.NoError(err)
+	}
+
+	for _, flags := range [][4]int{{0, 0, 0, 0}, {1, 1, 1, 1}, {1, 0, 1, 0}, {0, 1, 0, 1}} {
+		// the native digest of the selected data only
+		var msg []byte
+		var witness updateIfCircuit
+		for i := range data {
+			witness.Data[i] = data[i]
+			witness.Flags[i] = flags[i]
+			if flags[i] == 1 {
+				msg = append(msg, data[i].FillBytes(make([]byte, mimc_bn254.BlockSize))...)
+			}
+		}
+		expected, err := mimc_bn254.Sum(msg)
+		assert.NoError(err)
+		witness.Expected = expected
+
+		invalid := witness
+		invalid.Flags[2] = 1 - flags[2]
+
+		assert.CheckCircuit(&updateIfCircuit{},
+			test.WithValidAssignment(&witness),
+			test.WithInvalidAssignment(&invalid),
+			test.WithCurves(ecc.BN254))
+	}
+}
+
+type hashPaddedCircuit struct {
+	Inputs   [5]frontend.Variable
+	RealLen  frontend.Variable
+	Expected frontend.Variable
+}
+
+func (circuit *hashPaddedCircuit) Define(api frontend.API) error {
+	h, err := NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	d := HashPadded(api, h, circuit.Inputs[:], circuit.RealLen, len(circuit.Inputs))
+	api.AssertIsEqual(d, circuit.Expected)
+	return nil
+}
+
+func TestHashPadded(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	modulus := ecc.BN254.ScalarField()
+	var inputs [5]*big.Int
+	for i := range inputs {
+		var err error
+		inputs[i], err = rand.Int(rand.Reader, modulus)
+		assert.NoError(err)
+	}
+
+	for realLen := 0; realLen <= len(inputs); realLen++ {
+		// the native digest of the length followed by the real inputs
+		msg := big.NewInt(int64(realLen)).FillBytes(make([]byte, mimc_bn254.BlockSize))
+		for i := 0; i < realLen; i++ {
+			msg = append(msg, inputs[i].FillBytes(make([]byte, mimc_bn254.BlockSize))...)
+		}
+		expected, err := mimc_bn254.Sum(msg)
+		assert.NoError(err)
+
+		var witness hashPaddedCircuit
+		for i := range inputs {
+			witness.Inputs[i] = inputs[i]
+		}
+		witness.RealLen = realLen
+		witness.Expected = expected
+
+		// changing the padding does not change the digest
+		padded := witness
+		for i := realLen; i < len(inputs); i++ {
+			padded.Inputs[i] = i
+		}
+
+		// the length is part of the digest
+		invalid := witness
+		invalid.RealLen = (realLen + 1) % (len(inputs) + 1)
+
+		assert.CheckCircuit(&hashPaddedCircuit{},
+			test.WithValidAssignment(&witness),
+			test.WithValidAssignment(&padded),
+			test.WithInvalidAssignment(&invalid),
+			test.WithCurves(ecc.BN254))
+	}
+
+	// a length over maxLen is rejected, even with the digest of all the inputs
+	msg := big.NewInt(int64(len(inputs) + 1)).FillBytes(make([]byte, mimc_bn254.BlockSize))
+	witness := hashPaddedCircuit{RealLen: len(inputs) + 1}
+	for i := range inputs {
+		witness.Inputs[i] = inputs[i]
+		msg = append(msg, inputs[i].FillBytes(make([]byte, mimc_bn254.BlockSize))...)
+	}
+	expected, err := mimc_bn254.Sum(msg)
+	assert.NoError(err)
+	witness.Expected = expected
+	assert.Error(test.IsSolved(&hashPaddedCircuit{}, &witness, modulus))
+}
+
+type verifyChainCircuit struct {
+	Start frontend.Variable
+	Items []frontend.Variable
+	Head  frontend.Variable
+}
+
+func (circuit *verifyChainCircuit) Define(api frontend.API) error {
+	h, err := NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	VerifyChain(api, h, circuit.Start, circuit.Items, circuit.Head)
+	return nil
+}
+
+func TestVerifyChain(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	modulus := ecc.BN254.ScalarField()
+	start, err := rand.Int(rand.Reader, modulus)
+	assert.NoError(err)
+
+	for _, n := range []int{0, 1, 4} {
+		// the native chain
+		witness := verifyChainCircuit{Start: start, Items: make([]frontend.Variable, n)}
+		head := start.FillBytes(make([]byte, mimc_bn254.BlockSize))
+		for i := 0; i < n; i++ {
+			item, err := rand.Int(rand.Reader, modulus)
+			assert.NoError(err)
+			witness.Items[i] = item
+			head, err = mimc_bn254.Sum(append(head, item.FillBytes(make([]byte, mimc_bn254.BlockSize))...))
+			assert.NoError(err)
+		}
+		witness.Head = head
+
+		invalid := verifyChainCircuit{Start: start, Items: witness.Items, Head: new(big.Int).Add(new(big.Int).SetBytes(head), big.NewInt(1))}
+
+		assert.CheckCircuit(&verifyChainCircuit{Items: make([]frontend.Variable, n)},
+			test.WithValidAssignment(&witness),
+			test.WithInvalidAssignment(&invalid),
+			test.WithCurves(ecc.BN254))
+	}
+}
@@ -0,0 +1,74 @@
+// Package bitwords implements the operations on 32-bit words of the ARX
+// (addition, rotation, XOR) primitives, such as ChaCha20 and BLAKE2s.
+//
+// The words are decomposed into bits, so that the XORs are performed bitwise
+// and the rotations are free. The additions modulo 2³² are performed on the
+// native values, whose carries are dropped by decomposing the sums.
+package bitwords
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	fbits "github.com/consensys/gnark/std/math/bits"
+)
+
+// Word is a 32-bit word, least significant bit first. Its bits are constrained
+// to be boolean when it is built with the functions of this package.
+type Word [32]frontend.Variable
+
+// Constant returns the word of v.
+func Constant(v uint32) Word {
+	var w Word
+	for i := range w {
+		w[i] = (v >> i) & 1
+	}
+	return w
+}
+
+// FromValue returns the word of v, which is constrained to be on 32 bits.
+func FromValue(api frontend.API, v frontend.Variable) Word {
+	var w Word
+	copy(w[:], fbits.ToBinary(api, v, fbits.WithNbDigits(len(w))))
+	return w
+}
+
+// Value returns the native value of w.
+func Value(api frontend.API, w Word) frontend.Variable {
+	return fbits.FromBinary(api, w[:], fbits.WithUnconstrainedInputs())
+}
+
+// Add returns the sum of the words mod 2³². The carries are dropped.
+func Add(api frontend.API, words ...Word) Word {
+	var sum frontend.Variable = 0
+	for i := range words {
+		sum = api.Add(sum, Value(api, words[i]))
+	}
+	var w Word
+	nbCarryBits := bits.Len(uint(len(words) - 1))
+	copy(w[:], fbits.ToBinary(api, sum, fbits.WithNbDigits(len(w)+nbCarryBits)))
+	return w
+}
+
+// Xor returns a ⊕ b.
+func Xor(api frontend.API, a, b Word) Word {
+	var w Word
+	for i := range w {
+		w[i] = api.Xor(a[i], b[i])
+	}
+	return w
+}
+
+// RotL rotates w by n bits to the left.
+func RotL(w Word, n int) Word {
+	var res Word
+	for i := range w {
+		res[(i+n)%len(w)] = w[i]
+	}
+	return res
+}
+
+// RotR rotates w by n bits to the right.
+func RotR(w Word, n int) Word {
+	return RotL(w, len(w)-n%len(w))
+}
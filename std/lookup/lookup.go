@@ -0,0 +1,121 @@
+// Package lookup implements membership gadgets using lookup arguments.
+//
+// The lookups are checked with the log-derivative argument, as in package
+// logderivlookup, which is supported by all the backends through the commitment
+// of the queries.
+package lookup
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/kvstore"
+	"github.com/consensys/gnark/std/internal/logderivarg"
+	"github.com/consensys/gnark/std/math/field"
+	"github.com/consensys/gnark/std/multicommit"
+)
+
+// AssertIsInTable asserts that v is equal to one of the constants in table.
+// Duplicate entries are allowed. It panics if table is empty.
+//
+// The queries to the same table (with the same entries, in the same order) are
+// collected, and a single log-derivative argument is built for all of them
+// once the circuit is defined, using a commitment to the queries. The cost of
+// the table is then paid once per circuit, and every query only adds a few
+// constraints. Unlike selector.AssertIsInSet, whose cost per query grows with
+// the size of the table, it is the better choice for large tables queried
+// several times.
+func AssertIsInTable(api frontend.API, v frontend.Variable, table []*big.Int) {
+	if len(table) == 0 {
+		panic("lookup in empty table")
+	}
+	t := getMembershipTable(api, table)
+	t.queries = append(t.queries, v)
+}
+
+type ctxTableKey struct {
+	digest [sha256.Size]byte
+}
+
+// membershipTable collects the queries to a table of constants.
+type membershipTable struct {
+	entries []frontend.Variable
+	queries []frontend.Variable
+}
+
+// getMembershipTable returns the table of entries stored in the builder,
+// creating it on first use. The tables are identified by the digest of their
+// entries reduced modulo the native field.
+func getMembershipTable(api frontend.API, table []*big.Int) *membershipTable {
+	kv, ok := api.Compiler().(kvstore.Store)
+	if !ok {
+		panic("builder should implement key-value store")
+	}
+	entries := make([]frontend.Variable, len(table))
+	h := sha256.New()
+	var length [8]byte
+	for i := range table {
+		e := new(big.Int).Mod(table[i], api.Compiler().Field())
+		b := e.Bytes()
+		binary.BigEndian.PutUint64(length[:], uint64(len(b)))
+		h.Write(length[:])
+		h.Write(b)
+		entries[i] = e
+	}
+	var key ctxTableKey
+	copy(key.digest[:], h.Sum(nil))
+
+	if stored := kv.GetKeyValue(key); stored != nil {
+		t, ok := stored.(*membershipTable)
+		if !ok {
+			panic("stored membership table is not valid")
+		}
+		return t
+	}
+	t := &membershipTable{entries: entries}
+	kv.SetKeyValue(key, t)
+	api.Compiler().Defer(t.build)
+	return t
+}
+
+func (t *membershipTable) build(api frontend.API) error {
+	return logderivarg.Build(api, logderivarg.AsTable(t.entries), logderivarg.AsTable(t.queries))
+}
+
+// AssertSortedConcat asserts that s is a permutation of the concatenation of
+// the queries f and the table t, as required from the sorted list of the
+// plookup argument. The multisets are compared with the grand product
+//
+//	∏ᵢ (γ-f[i]) · ∏ᵢ (γ-t[i]) / ∏ᵢ (γ-s[i]) == 1,
+//
+// where the challenge γ is derived from a commitment to f, t and s. Only the
+// multiset equality is checked: the order of s, which plookup checks on the
+// differences of its consecutive values, is not. It panics if the length of s
+// isn't the sum of the lengths of f and t.
+func AssertSortedConcat(api frontend.API, f, t, s []frontend.Variable) {
+	if len(s) != len(f)+len(t) {
+		panic(fmt.Sprintf("sorted list of length %d for %d queries and %d table entries", len(s), len(f), len(t)))
+	}
+	toCommit := make([]frontend.Variable, 0, 2*len(s))
+	toCommit = append(toCommit, f...)
+	toCommit = append(toCommit, t...)
+	toCommit = append(toCommit, s...)
+	multicommit.WithCommitment(api, func(api frontend.API, gamma frontend.Variable) error {
+		num := make([]frontend.Variable, 0, len(s))
+		for _, v := range f {
+			num = append(num, api.Sub(gamma, v))
+		}
+		for _, v := range t {
+			num = append(num, api.Sub(gamma, v))
+		}
+		den := make([]frontend.Variable, len(s))
+		for i := range s {
+			den[i] = api.Sub(gamma, s[i])
+		}
+		api.AssertIsEqual(field.GrandProduct(api, num, den), 1)
+		return nil
+	}, toCommit...)
+}
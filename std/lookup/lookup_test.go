@@ -0,0 +1,130 @@
+package lookup
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+	"github.com/stretchr/testify/require"
+)
+
+type isInTableCircuit struct {
+	V     frontend.Variable
+	table []*big.Int
+}
+
+func (c *isInTableCircuit) Define(api frontend.API) error {
+	AssertIsInTable(api, c.V, c.table)
+	return nil
+}
+
+func TestAssertIsInTable(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// the odd numbers below 2000
+	table := make([]*big.Int, 1000)
+	for i := range table {
+		table[i] = big.NewInt(int64(2*i + 1))
+	}
+
+	assert.CheckCircuit(&isInTableCircuit{table: table},
+		test.WithValidAssignment(&isInTableCircuit{V: 1}),
+		test.WithValidAssignment(&isInTableCircuit{V: 1001}),
+		test.WithValidAssignment(&isInTableCircuit{V: 1999}),
+		test.WithInvalidAssignment(&isInTableCircuit{V: 0}),
+		test.WithInvalidAssignment(&isInTableCircuit{V: 1000}),
+		test.WithInvalidAssignment(&isInTableCircuit{V: 2001}),
+		test.WithCurves(ecc.BN254))
+}
+
+type isInTablesCircuit struct {
+	V          []frontend.Variable
+	W          frontend.Variable
+	table      []*big.Int
+	otherTable []*big.Int
+}
+
+func (c *isInTablesCircuit) Define(api frontend.API) error {
+	for i := range c.V {
+		// a copy of the table is the same table
+		table := make([]*big.Int, len(c.table))
+		copy(table, c.table)
+		AssertIsInTable(api, c.V[i], table)
+	}
+	if c.otherTable != nil {
+		AssertIsInTable(api, c.W, c.otherTable)
+	}
+	return nil
+}
+
+func TestAssertIsInTableSeveralQueries(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	table := make([]*big.Int, 1000)
+	for i := range table {
+		table[i] = big.NewInt(int64(2*i + 1))
+	}
+	otherTable := []*big.Int{big.NewInt(2), big.NewInt(4)}
+
+	assert.CheckCircuit(&isInTablesCircuit{V: make([]frontend.Variable, 3), table: table, otherTable: otherTable},
+		test.WithValidAssignment(&isInTablesCircuit{V: []frontend.Variable{1, 1001, 1999}, W: 4}),
+		test.WithInvalidAssignment(&isInTablesCircuit{V: []frontend.Variable{1, 1000, 1999}, W: 4}),
+		test.WithInvalidAssignment(&isInTablesCircuit{V: []frontend.Variable{1, 1001, 1999}, W: 3}),
+		test.WithCurves(ecc.BN254))
+}
+
+func TestAssertIsInTableConstraintCount(t *testing.T) {
+	assert := require.New(t)
+
+	table := make([]*big.Int, 1000)
+	for i := range table {
+		table[i] = big.NewInt(int64(2*i + 1))
+	}
+	for _, builder := range []frontend.NewBuilder{r1cs.NewBuilder, scs.NewBuilder} {
+		nbConstraints := func(nbQueries int) int {
+			ccs, err := frontend.Compile(ecc.BN254.ScalarField(), builder, &isInTablesCircuit{V: make([]frontend.Variable, nbQueries), table: table})
+			assert.NoError(err)
+			return ccs.GetNbConstraints()
+		}
+		// the table is built once, each query only adds a few constraints
+		one, two, four := nbConstraints(1), nbConstraints(2), nbConstraints(4)
+		perQuery := two - one
+		assert.Equal(one+3*perQuery, four)
+		assert.Less(perQuery, 10)
+	}
+}
+
+type sortedConcatCircuit struct {
+	F [2]frontend.Variable
+	T [4]frontend.Variable
+	S [6]frontend.Variable
+}
+
+func (c *sortedConcatCircuit) Define(api frontend.API) error {
+	AssertSortedConcat(api, c.F[:], c.T[:], c.S[:])
+	return nil
+}
+
+func TestAssertSortedConcat(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	table := [4]frontend.Variable{1, 2, 3, 4}
+	assert.CheckCircuit(&sortedConcatCircuit{},
+		test.WithValidAssignment(&sortedConcatCircuit{F: [2]frontend.Variable{3, 1}, T: table, S: [6]frontend.Variable{1, 1, 2, 3, 3, 4}}),
+		test.WithValidAssignment(&sortedConcatCircuit{F: [2]frontend.Variable{4, 4}, T: table, S: [6]frontend.Variable{1, 2, 3, 4, 4, 4}}),
+		// a value of f is missing from s
+		test.WithInvalidAssignment(&sortedConcatCircuit{F: [2]frontend.Variable{3, 1}, T: table, S: [6]frontend.Variable{1, 2, 2, 3, 3, 4}}),
+		// a value of t is missing from s
+		test.WithInvalidAssignment(&sortedConcatCircuit{F: [2]frontend.Variable{3, 1}, T: table, S: [6]frontend.Variable{1, 1, 3, 3, 3, 4}}),
+		// a value of s is in neither f nor t
+		test.WithInvalidAssignment(&sortedConcatCircuit{F: [2]frontend.Variable{3, 1}, T: table, S: [6]frontend.Variable{1, 1, 2, 3, 3, 5}}),
+		test.WithCurves(ecc.BN254))
+
+	assert.Panics(func() {
+		AssertSortedConcat(nil, make([]frontend.Variable, 2), make([]frontend.Variable, 4), make([]frontend.Variable, 5))
+	})
+}
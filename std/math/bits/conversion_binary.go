@@ -16,6 +16,17 @@ func FromBinary(api frontend.API, digits []frontend.Variable, opts ...BaseConver
 	return FromBase(api, Binary, digits, opts...)
 }
 
+// ToBinaryCapped decomposes v into n bits, little-endian. It asserts that v
+// equals the weighted sum of the returned bits, so that the circuit is not
+// satisfiable when v ≥ 2ⁿ. It is cheaper than a full-width decomposition when
+// v is known to be bounded. It panics if n is not positive.
+func ToBinaryCapped(api frontend.API, v frontend.Variable, n int) []frontend.Variable {
+	if n <= 0 {
+		panic("ToBinaryCapped: the number of bits must be positive")
+	}
+	return ToBinary(api, v, WithNbDigits(n))
+}
+
 func fromBinary(api frontend.API, digits []frontend.Variable, opts ...BaseConversionOption) frontend.Variable {
 
 	cfg := baseConversionConfig{}
@@ -1,6 +1,8 @@
 package bits_test
 
 import (
+	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark/frontend"
@@ -40,6 +42,36 @@ func TestToBinary(t *testing.T) {
 
 }
 
+type toBinaryCappedCircuit struct {
+	A frontend.Variable
+	n int
+}
+
+func (c *toBinaryCappedCircuit) Define(api frontend.API) error {
+	b := bits.ToBinaryCapped(api, c.A, c.n)
+	if len(b) != c.n {
+		return fmt.Errorf("expected %d bits, got %d", c.n, len(b))
+	}
+	api.AssertIsEqual(bits.FromBinary(api, b), c.A)
+	return nil
+}
+
+func TestToBinaryCapped(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, n := range []int{1, 8, 64} {
+		bound := new(big.Int).Lsh(big.NewInt(1), uint(n))
+		max := new(big.Int).Sub(bound, big.NewInt(1))
+		assert.Run(func(assert *test.Assert) {
+			assert.CheckCircuit(&toBinaryCappedCircuit{n: n},
+				test.WithValidAssignment(&toBinaryCappedCircuit{A: 0}),
+				test.WithValidAssignment(&toBinaryCappedCircuit{A: max}),
+				test.WithInvalidAssignment(&toBinaryCappedCircuit{A: bound}),
+			)
+		}, fmt.Sprintf("n=%d", n))
+	}
+}
+
 type toTernaryCircuit struct {
 	A          frontend.Variable
 	T0, T1, T2 frontend.Variable
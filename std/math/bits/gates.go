@@ -0,0 +1,23 @@
+package bits
+
+import "github.com/consensys/gnark/frontend"
+
+// The gates below complete api.And, api.Or and api.Xor into the full set of
+// symmetric two-input boolean gates. As the gates they are built on, they
+// assert that a and b are boolean. The negation 1 - x is a linear expression,
+// so that each gate costs the same as the gate it negates.
+
+// Nand returns ¬(a ∧ b).
+func Nand(api frontend.API, a, b frontend.Variable) frontend.Variable {
+	return api.Sub(1, api.And(a, b))
+}
+
+// Nor returns ¬(a ∨ b).
+func Nor(api frontend.API, a, b frontend.Variable) frontend.Variable {
+	return api.Sub(1, api.Or(a, b))
+}
+
+// Xnor returns ¬(a ⊕ b), that is 1 if a == b and 0 otherwise.
+func Xnor(api frontend.API, a, b frontend.Variable) frontend.Variable {
+	return api.Sub(1, api.Xor(a, b))
+}
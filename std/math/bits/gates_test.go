@@ -0,0 +1,49 @@
+package bits_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/test"
+)
+
+type gatesCircuit struct {
+	A, B            frontend.Variable
+	Nand, Nor, Xnor frontend.Variable
+}
+
+func (c *gatesCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(bits.Nand(api, c.A, c.B), c.Nand)
+	api.AssertIsEqual(bits.Nor(api, c.A, c.B), c.Nor)
+	api.AssertIsEqual(bits.Xnor(api, c.A, c.B), c.Xnor)
+	return nil
+}
+
+func TestGates(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	// truth table: a, b, nand, nor, xnor
+	table := [4][5]int{
+		{0, 0, 1, 1, 1},
+		{0, 1, 1, 0, 0},
+		{1, 0, 1, 0, 0},
+		{1, 1, 0, 0, 1},
+	}
+	for _, row := range table {
+		valid := gatesCircuit{A: row[0], B: row[1], Nand: row[2], Nor: row[3], Xnor: row[4]}
+		opts := []test.TestingOption{test.WithValidAssignment(&valid)}
+		for _, flip := range []int{2, 3, 4} {
+			invalid := row
+			invalid[flip] = 1 - invalid[flip]
+			opts = append(opts, test.WithInvalidAssignment(&gatesCircuit{
+				A: invalid[0], B: invalid[1], Nand: invalid[2], Nor: invalid[3], Xnor: invalid[4],
+			}))
+		}
+		assert.CheckCircuit(&gatesCircuit{}, opts...)
+	}
+
+	// non-boolean inputs are rejected
+	assert.CheckCircuit(&gatesCircuit{},
+		test.WithInvalidAssignment(&gatesCircuit{A: 2, B: 0, Nand: -1, Nor: -1, Xnor: -1}))
+}
@@ -0,0 +1,36 @@
+package bits
+
+import "github.com/consensys/gnark/frontend"
+
+// Log2Floor returns ⌊log₂(v)⌋, the index of the most significant set bit of v.
+// v must fit on maxBits bits, which is asserted. By convention, Log2Floor
+// returns 0 when v is 0, as when v is 1.
+//
+// v is decomposed into bits, and the index of the most significant set bit is
+// selected with a priority encoder, scanning the bits from the most
+// significant one.
+func Log2Floor(api frontend.API, v frontend.Variable, maxBits int) frontend.Variable {
+	b := ToBinary(api, v, WithNbDigits(maxBits))
+
+	// seen is 1 once a set bit has been encountered
+	seen := frontend.Variable(0)
+	res := frontend.Variable(0)
+	for i := maxBits - 1; i > 0; i-- {
+		// isTop is 1 for the most significant set bit only
+		isTop := api.Mul(b[i], api.Sub(1, seen))
+		res = api.Add(res, api.Mul(isTop, i))
+		seen = api.Add(seen, isTop)
+	}
+	return res
+}
+
+// Log2Ceil returns ⌈log₂(v)⌉, the number of bits needed to represent the
+// integers in [0, v-1]. v must fit on maxBits bits, which is asserted. By
+// convention, Log2Ceil returns 0 when v is 0, as when v is 1.
+func Log2Ceil(api frontend.API, v frontend.Variable, maxBits int) frontend.Variable {
+	// ⌈log₂(v)⌉ = ⌊log₂(v-1)⌋+1 for v > 1
+	vIsZero := api.IsZero(v)
+	w := api.Select(vIsZero, 0, api.Sub(v, 1))
+	floor := Log2Floor(api, w, maxBits)
+	return api.Select(api.IsZero(w), 0, api.Add(floor, 1))
+}
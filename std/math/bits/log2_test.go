@@ -0,0 +1,40 @@
+package bits_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/test"
+)
+
+type log2Circuit struct {
+	V, Floor, Ceil frontend.Variable
+}
+
+func (c *log2Circuit) Define(api frontend.API) error {
+	api.AssertIsEqual(bits.Log2Floor(api, c.V, 16), c.Floor)
+	api.AssertIsEqual(bits.Log2Ceil(api, c.V, 16), c.Ceil)
+	return nil
+}
+
+func TestLog2(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	opts := []test.TestingOption{
+		test.WithValidAssignment(&log2Circuit{V: 0, Floor: 0, Ceil: 0}),
+		test.WithValidAssignment(&log2Circuit{V: 3, Floor: 1, Ceil: 2}),
+		test.WithValidAssignment(&log2Circuit{V: 0xffff, Floor: 15, Ceil: 16}),
+		test.WithInvalidAssignment(&log2Circuit{V: 5, Floor: 3, Ceil: 3}),
+		test.WithInvalidAssignment(&log2Circuit{V: 5, Floor: 2, Ceil: 2}),
+		test.WithInvalidAssignment(&log2Circuit{V: 0x10000, Floor: 16, Ceil: 16}),
+	}
+	opts = append(opts, test.WithValidAssignment(&log2Circuit{V: 1, Floor: 0, Ceil: 0}))
+	for i := 1; i < 16; i++ {
+		opts = append(opts,
+			test.WithValidAssignment(&log2Circuit{V: 1 << i, Floor: i, Ceil: i}),
+			test.WithValidAssignment(&log2Circuit{V: 1<<i + 1, Floor: i, Ceil: i + 1}),
+		)
+	}
+	assert.CheckCircuit(&log2Circuit{}, opts...)
+}
@@ -0,0 +1,30 @@
+package bits
+
+import "github.com/consensys/gnark/frontend"
+
+// Reverse returns the bits of a decomposition in reverse order, so that a
+// little-endian decomposition becomes big-endian and conversely. It only
+// rewires the variables and adds no constraint.
+func Reverse(bits []frontend.Variable) []frontend.Variable {
+	res := make([]frontend.Variable, len(bits))
+	for i := range bits {
+		res[i] = bits[len(bits)-1-i]
+	}
+	return res
+}
+
+// ReverseBytes returns the bytes of a decomposition in reverse order, keeping
+// the order of the bits in each byte, which switches the byte order of a word.
+// It only rewires the variables and adds no constraint. It panics if the
+// number of bits is not a multiple of 8.
+func ReverseBytes(bits []frontend.Variable) []frontend.Variable {
+	if len(bits)%8 != 0 {
+		panic("ReverseBytes: the number of bits must be a multiple of 8")
+	}
+	res := make([]frontend.Variable, len(bits))
+	nbBytes := len(bits) / 8
+	for i := 0; i < nbBytes; i++ {
+		copy(res[8*i:8*(i+1)], bits[8*(nbBytes-1-i):8*(nbBytes-i)])
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package bits_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/test"
+)
+
+func TestReversePermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	in := make([]frontend.Variable, 16)
+	for i := range in {
+		in[i] = i
+	}
+
+	reversed := bits.Reverse(in)
+	for i := range in {
+		assert.Equal(15-i, reversed[i])
+	}
+
+	// bytes are swapped, the bits in each byte keep their order
+	swapped := bits.ReverseBytes(in)
+	for i := range in {
+		assert.Equal((1-i/8)*8+i%8, swapped[i])
+	}
+
+	assert.Panics(func() { bits.ReverseBytes(in[:12]) })
+}
+
+type reverseCircuit struct {
+	A           frontend.Variable
+	Reversed    frontend.Variable
+	ByteSwapped frontend.Variable
+}
+
+func (c *reverseCircuit) Define(api frontend.API) error {
+	b := bits.ToBinary(api, c.A, bits.WithNbDigits(16))
+	api.AssertIsEqual(bits.FromBinary(api, bits.Reverse(b)), c.Reversed)
+	api.AssertIsEqual(bits.FromBinary(api, bits.ReverseBytes(b)), c.ByteSwapped)
+	return nil
+}
+
+func TestReverse(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.CheckCircuit(&reverseCircuit{},
+		test.WithValidAssignment(&reverseCircuit{A: 0x0001, Reversed: 0x8000, ByteSwapped: 0x0100}),
+		test.WithValidAssignment(&reverseCircuit{A: 0x12f0, Reversed: 0x0f48, ByteSwapped: 0xf012}),
+		test.WithInvalidAssignment(&reverseCircuit{A: 0x12f0, Reversed: 0x12f0, ByteSwapped: 0xf012}),
+		test.WithInvalidAssignment(&reverseCircuit{A: 0x12f0, Reversed: 0x0f48, ByteSwapped: 0x12f0}),
+	)
+
+	// reversing adds no constraint to the decomposition
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &reverseCircuit{})
+	assert.NoError(err)
+	ref, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &notReversedCircuit{})
+	assert.NoError(err)
+	assert.Equal(ref.GetNbConstraints(), ccs.GetNbConstraints())
+}
+
+type notReversedCircuit reverseCircuit
+
+func (c *notReversedCircuit) Define(api frontend.API) error {
+	b := bits.ToBinary(api, c.A, bits.WithNbDigits(16))
+	api.AssertIsEqual(bits.FromBinary(api, b), c.Reversed)
+	api.AssertIsEqual(bits.FromBinary(api, b), c.ByteSwapped)
+	return nil
+}
@@ -0,0 +1,34 @@
+package bits
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// XorN returns the bitwise XOR of a and b seen as n-bit integers. It asserts
+// that a and b are less than 2ⁿ. n must be less than the bit length of the
+// field, so that the result is always a field element.
+func XorN(api frontend.API, a, b frontend.Variable, n int) frontend.Variable {
+	if n <= 0 || n >= api.Compiler().FieldBitLen() {
+		panic(fmt.Sprintf("XorN: %d bits must be between 1 and %d", n, api.Compiler().FieldBitLen()-1))
+	}
+	aBits := ToBinary(api, a, WithNbDigits(n))
+	bBits := ToBinary(api, b, WithNbDigits(n))
+	res := make([]frontend.Variable, n)
+	for i := range res {
+		res[i] = api.Xor(aBits[i], bBits[i])
+	}
+	return FromBinary(api, res, WithUnconstrainedInputs())
+}
+
+// Xor256 returns the bitwise XOR of a and b seen as 256-bit integers. It
+// asserts that a and b are less than 2²⁵⁶.
+//
+// A field element only holds any 256-bit integer in a field larger than 256
+// bits, such as the scalar field of BW6-761, and Xor256 panics otherwise. On
+// smaller fields, such as the scalar field of BN254, 256-bit words are split in
+// bytes or limbs, see package [github.com/consensys/gnark/std/math/uints].
+func Xor256(api frontend.API, a, b frontend.Variable) frontend.Variable {
+	return XorN(api, a, b, 256)
+}
@@ -0,0 +1,68 @@
+package bits_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/test"
+)
+
+type xorNCircuit struct {
+	A, B, Res frontend.Variable
+	n         int
+}
+
+func (c *xorNCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(bits.XorN(api, c.A, c.B, c.n), c.Res)
+	return nil
+}
+
+type xor256Circuit struct {
+	A, B, Res frontend.Variable
+}
+
+func (c *xor256Circuit) Define(api frontend.API) error {
+	api.AssertIsEqual(bits.Xor256(api, c.A, c.B), c.Res)
+	return nil
+}
+
+func randomXor(t *testing.T, n int) (a, b, res *big.Int) {
+	bound := new(big.Int).Lsh(big.NewInt(1), uint(n))
+	a, err := rand.Int(rand.Reader, bound)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err = rand.Int(rand.Reader, bound)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a, b, new(big.Int).Xor(a, b)
+}
+
+func TestXorN(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n = 64
+
+	a, b, res := randomXor(t, n)
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), n)
+	assert.CheckCircuit(&xorNCircuit{n: n},
+		test.WithValidAssignment(&xorNCircuit{A: a, B: b, Res: res}),
+		test.WithInvalidAssignment(&xorNCircuit{A: a, B: b, Res: new(big.Int).Add(res, big.NewInt(1))}),
+		test.WithInvalidAssignment(&xorNCircuit{A: tooLarge, B: b, Res: new(big.Int).Xor(tooLarge, b)}),
+		test.WithCurves(ecc.BN254))
+}
+
+func TestXor256(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	a, b, res := randomXor(t, 256)
+	assert.NoError(test.IsSolved(&xor256Circuit{}, &xor256Circuit{A: a, B: b, Res: res}, ecc.BW6_761.ScalarField()))
+	assert.Error(test.IsSolved(&xor256Circuit{}, &xor256Circuit{A: a, B: b, Res: a}, ecc.BW6_761.ScalarField()))
+
+	// 256-bit words do not fit in the scalar field of BN254
+	assert.Error(test.IsSolved(&xor256Circuit{}, &xor256Circuit{A: 1, B: 2, Res: 3}, ecc.BN254.ScalarField()))
+}
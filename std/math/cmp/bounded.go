@@ -16,7 +16,7 @@ func init() {
 // GetHints returns all hint functions used in this package. This method is
 // useful for registering all hints in the solver.
 func GetHints() []solver.Hint {
-	return []solver.Hint{isLessOutputHint, minOutputHint}
+	return []solver.Hint{isLessOutputHint, minOutputHint, differenceInverseHint}
 }
 
 // BoundedComparator provides comparison methods, with relatively low circuit
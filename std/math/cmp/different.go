@@ -0,0 +1,34 @@
+package cmp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// AssertIsDifferent defines a set of constraints that can be satisfied only if
+// a != b. It asserts that a - b has an inverse, which is provided by a hint.
+//
+// When a == b, the hint fails and no proof can be generated.
+func AssertIsDifferent(api frontend.API, a, b frontend.Variable) {
+	diff := api.Sub(a, b)
+	res, err := api.Compiler().NewHint(differenceInverseHint, 1, diff)
+	if err != nil {
+		panic(fmt.Sprintf("error in calling differenceInverseHint: %v", err))
+	}
+	// (a - b) * (a - b)⁻¹ == 1
+	api.AssertIsEqual(api.Mul(diff, res[0]), 1)
+}
+
+// differenceInverseHint returns the inverse of a - b, used by
+// [AssertIsDifferent].
+func differenceInverseHint(fieldOrder *big.Int, inputs, results []*big.Int) error {
+	diff := new(big.Int).Mod(inputs[0], fieldOrder)
+	if diff.Sign() == 0 {
+		return errors.New("AssertIsDifferent: the two values are equal")
+	}
+	results[0].ModInverse(diff, fieldOrder)
+	return nil
+}
@@ -0,0 +1,28 @@
+package cmp_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/cmp"
+	"github.com/consensys/gnark/test"
+)
+
+type assertIsDifferentCircuit struct {
+	A, B frontend.Variable
+}
+
+func (c *assertIsDifferentCircuit) Define(api frontend.API) error {
+	cmp.AssertIsDifferent(api, c.A, c.B)
+	return nil
+}
+
+func TestAssertIsDifferent(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.ProverSucceeded(&assertIsDifferentCircuit{}, &assertIsDifferentCircuit{A: 2, B: 3})
+	assert.ProverSucceeded(&assertIsDifferentCircuit{}, &assertIsDifferentCircuit{A: 0, B: -1})
+
+	assert.ProverFailed(&assertIsDifferentCircuit{}, &assertIsDifferentCircuit{A: 5, B: 5})
+	assert.ProverFailed(&assertIsDifferentCircuit{}, &assertIsDifferentCircuit{A: 0, B: 0})
+}
@@ -51,6 +51,72 @@ func IsLessOrEqualBinary(api frontend.API, aBits, bBits []frontend.Variable) fro
 	return isLessRecursive(api, aBits, bBits, true, true)
 }
 
+// AbsDiff returns |a - b|. a and b must be non-negative integers of at most
+// bitLen bits, which is asserted. It compares a and b and selects either a - b
+// or b - a accordingly.
+func AbsDiff(api frontend.API, a, b frontend.Variable, bitLen int) frontend.Variable {
+	aBits := bits.ToBinary(api, a, bits.WithNbDigits(bitLen))
+	bBits := bits.ToBinary(api, b, bits.WithNbDigits(bitLen))
+	aLess := isLessRecursive(api, aBits, bBits, false, true)
+	return api.Select(aLess, api.Sub(b, a), api.Sub(a, b))
+}
+
+// Min returns the minimum of values. The values must be non-negative integers
+// of at most bitLen bits, which is asserted. The minimum is obtained by folding
+// pairwise comparisons over values. It panics if values is empty.
+func Min(api frontend.API, values []frontend.Variable, bitLen int) frontend.Variable {
+	return extremum(api, values, bitLen, false)
+}
+
+// Max returns the maximum of values. The values must be non-negative integers
+// of at most bitLen bits, which is asserted. The maximum is obtained by folding
+// pairwise comparisons over values. It panics if values is empty.
+func Max(api frontend.API, values []frontend.Variable, bitLen int) frontend.Variable {
+	return extremum(api, values, bitLen, true)
+}
+
+// AssertStrictlyIncreasing asserts that values[i] < values[i+1] for every i.
+// The values must be non-negative integers of at most bitLen bits, which is
+// asserted, so that their differences can be compared with a single
+// BoundedComparator. Slices of less than two elements trivially pass.
+func AssertStrictlyIncreasing(api frontend.API, values []frontend.Variable, bitLen int) {
+	if len(values) < 2 {
+		return
+	}
+	for _, v := range values {
+		bits.ToBinary(api, v, bits.WithNbDigits(bitLen))
+	}
+	diffBound := new(big.Int).Lsh(big.NewInt(1), uint(bitLen))
+	diffBound.Sub(diffBound, big.NewInt(1))
+	comparator := NewBoundedComparator(api, diffBound, false)
+	for i := 0; i < len(values)-1; i++ {
+		comparator.AssertIsLess(values[i], values[i+1])
+	}
+}
+
+// extremum returns the minimum of values, or the maximum when max is true.
+func extremum(api frontend.API, values []frontend.Variable, bitLen int, max bool) frontend.Variable {
+	if len(values) == 0 {
+		panic("extremum of an empty slice")
+	}
+	res := values[0]
+	resBits := bits.ToBinary(api, res, bits.WithNbDigits(bitLen))
+	for _, v := range values[1:] {
+		vBits := bits.ToBinary(api, v, bits.WithNbDigits(bitLen))
+		var replace frontend.Variable
+		if max {
+			replace = isLessRecursive(api, resBits, vBits, false, true)
+		} else {
+			replace = isLessRecursive(api, vBits, resBits, false, true)
+		}
+		res = api.Select(replace, v, res)
+		for i := range resBits {
+			resBits[i] = api.Select(replace, vBits[i], resBits[i])
+		}
+	}
+	return res
+}
+
 // isLessRecursive compares binary numbers a and b. When useBoundedCmp is false
 // it performs normal bit by bit comparison which defines 2*n multiplication
 // constraints. When useBoundedCmp is true, bit by bit comparison will be used
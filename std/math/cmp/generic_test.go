@@ -166,3 +166,104 @@ func Test_IsLessUnsigned(t *testing.T) {
 		WantLessEq: 1,
 	}, test.WithCurves(ecc.BN254))
 }
+
+type absDiffCircuit struct {
+	A, B, Want frontend.Variable
+}
+
+func (c *absDiffCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.Want, AbsDiff(api, c.A, c.B, 8))
+	return nil
+}
+
+func TestAbsDiff(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.CheckCircuit(&absDiffCircuit{},
+		test.WithValidAssignment(&absDiffCircuit{A: 3, B: 10, Want: 7}),
+		test.WithValidAssignment(&absDiffCircuit{A: 255, B: 0, Want: 255}),
+		test.WithValidAssignment(&absDiffCircuit{A: 42, B: 42, Want: 0}),
+		test.WithInvalidAssignment(&absDiffCircuit{A: 3, B: 10, Want: -7}),
+		test.WithInvalidAssignment(&absDiffCircuit{A: 256, B: 0, Want: 256}),
+	)
+}
+
+type extremumCircuit struct {
+	Values           [4]frontend.Variable
+	WantMin, WantMax frontend.Variable
+}
+
+func (c *extremumCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.WantMin, Min(api, c.Values[:], 8))
+	api.AssertIsEqual(c.WantMax, Max(api, c.Values[:], 8))
+	return nil
+}
+
+type singleExtremumCircuit struct {
+	Value frontend.Variable
+}
+
+func (c *singleExtremumCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(c.Value, Min(api, []frontend.Variable{c.Value}, 8))
+	api.AssertIsEqual(c.Value, Max(api, []frontend.Variable{c.Value}, 8))
+	return nil
+}
+
+func TestMinMax(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	values := func(v ...frontend.Variable) (res [4]frontend.Variable) {
+		copy(res[:], v)
+		return
+	}
+	assert.CheckCircuit(&extremumCircuit{},
+		test.WithValidAssignment(&extremumCircuit{Values: values(3, 10, 0, 7), WantMin: 0, WantMax: 10}),
+		test.WithValidAssignment(&extremumCircuit{Values: values(255, 1, 254, 2), WantMin: 1, WantMax: 255}),
+		test.WithValidAssignment(&extremumCircuit{Values: values(42, 42, 42, 42), WantMin: 42, WantMax: 42}),
+		test.WithInvalidAssignment(&extremumCircuit{Values: values(3, 10, 0, 7), WantMin: 3, WantMax: 10}),
+		test.WithInvalidAssignment(&extremumCircuit{Values: values(3, 10, 0, 7), WantMin: 0, WantMax: 7}),
+		test.WithInvalidAssignment(&extremumCircuit{Values: values(3, 256, 0, 7), WantMin: 0, WantMax: 256}),
+		test.WithInvalidAssignment(&extremumCircuit{Values: values(3, -1, 0, 7), WantMin: -1, WantMax: 7}),
+		test.WithCurves(ecc.BN254),
+	)
+
+	assert.CheckCircuit(&singleExtremumCircuit{},
+		test.WithValidAssignment(&singleExtremumCircuit{Value: 5}),
+		test.WithCurves(ecc.BN254),
+	)
+
+	assert.Panics(func() {
+		Min(nil, nil, 8)
+	})
+}
+
+type strictlyIncreasingCircuit struct {
+	Values []frontend.Variable
+}
+
+func (c *strictlyIncreasingCircuit) Define(api frontend.API) error {
+	AssertStrictlyIncreasing(api, c.Values, 8)
+	return nil
+}
+
+func TestAssertStrictlyIncreasing(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	values := func(v ...frontend.Variable) *strictlyIncreasingCircuit {
+		return &strictlyIncreasingCircuit{Values: v}
+	}
+	assert.CheckCircuit(&strictlyIncreasingCircuit{Values: make([]frontend.Variable, 4)},
+		test.WithValidAssignment(values(0, 1, 2, 3)),
+		test.WithValidAssignment(values(3, 10, 100, 255)),
+		test.WithInvalidAssignment(values(0, 1, 1, 3)),
+		test.WithInvalidAssignment(values(0, 2, 1, 3)),
+		test.WithInvalidAssignment(values(0, 1, 2, 256)),
+		test.WithInvalidAssignment(values(-1, 1, 2, 3)),
+		test.WithCurves(ecc.BN254),
+	)
+
+	assert.CheckCircuit(&strictlyIncreasingCircuit{Values: make([]frontend.Variable, 1)},
+		test.WithValidAssignment(values(200)),
+		test.WithCurves(ecc.BN254),
+	)
+}
@@ -94,6 +94,14 @@ func (e *Element[T]) GnarkInitHook() {
 	}
 }
 
+// Overflow returns the number of bits by which the limbs of e may exceed the
+// width of the limbs of the field parameters: every limb of e is less than
+// 2^(BitsPerLimb+Overflow). It grows with the additions and subtractions until
+// e is reduced with [Field[T].Reduce].
+func (e *Element[T]) Overflow() uint {
+	return e.overflow
+}
+
 // copy makes a deep copy of the element.
 func (e *Element[T]) copy() *Element[T] {
 	r := Element[T]{}
@@ -8,10 +8,15 @@ import (
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	fp_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
 	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/std/math/emulated/emparams"
 	"github.com/consensys/gnark/test"
 )
 
@@ -281,6 +286,85 @@ func testReduceAfterAdd[T FieldParams](t *testing.T) {
 	}, testName[T]())
 }
 
+type AddChainNoReduceCircuit[T FieldParams] struct {
+	A []Element[T]
+	B Element[T]
+	C Element[T]
+}
+
+func (c *AddChainNoReduceCircuit[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	res := &c.A[0]
+	for i := 1; i < len(c.A); i++ {
+		res = f.AddNoReduce(res, &c.A[i])
+		if res.Overflow() != uint(i) {
+			return fmt.Errorf("overflow %d after %d additions", res.Overflow(), i)
+		}
+	}
+	res = f.SubNoReduce(res, &c.B)
+	f.AssertLimbsInRange(res)
+	res = f.Reduce(res)
+	if res.Overflow() != 0 {
+		return fmt.Errorf("overflow %d after reduction", res.Overflow())
+	}
+	f.AssertIsEqual(res, &c.C)
+	return nil
+}
+
+func TestAddChainNoReduce(t *testing.T) {
+	testAddChainNoReduce[Goldilocks](t)
+	testAddChainNoReduce[Secp256k1Fp](t)
+	testAddChainNoReduce[BN254Fp](t)
+}
+
+func testAddChainNoReduce[T FieldParams](t *testing.T) {
+	var fp T
+	const n = 10
+	assert := test.NewAssert(t)
+	assert.Run(func(assert *test.Assert) {
+		circuit := AddChainNoReduceCircuit[T]{A: make([]Element[T], n)}
+		witness := AddChainNoReduceCircuit[T]{A: make([]Element[T], n)}
+		res := new(big.Int)
+		for i := range witness.A {
+			val, _ := rand.Int(rand.Reader, fp.Modulus())
+			witness.A[i] = ValueOf[T](val)
+			res.Add(res, val)
+		}
+		val, _ := rand.Int(rand.Reader, fp.Modulus())
+		witness.B = ValueOf[T](val)
+		res.Sub(res, val).Mod(res, fp.Modulus())
+		witness.C = ValueOf[T](res)
+		assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness))
+	}, testName[T]())
+}
+
+type AddNoReduceOverflowCircuit[T FieldParams] struct {
+	A Element[T]
+}
+
+func (c *AddNoReduceOverflowCircuit[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	res := &c.A
+	for i := 0; i < api.Compiler().FieldBitLen(); i++ {
+		res = f.AddNoReduce(res, res)
+	}
+	f.AssertIsEqual(res, &c.A)
+	return nil
+}
+
+func TestAddNoReduceOverflow(t *testing.T) {
+	_, err := frontend.Compile(testCurve.ScalarField(), r1cs.NewBuilder, &AddNoReduceOverflowCircuit[Secp256k1Fp]{})
+	if err == nil {
+		t.Fatal("expected an overflow error")
+	}
+}
+
 type SubtractCircuit[T FieldParams] struct {
 	A Element[T]
 	B Element[T]
@@ -956,3 +1040,145 @@ func testSqrt[T FieldParams](t *testing.T) {
 		assert.ProverSucceeded(&SqrtCircuit[T]{}, &SqrtCircuit[T]{X: ValueOf[T](X), Expected: ValueOf[T](exp)}, test.WithCurves(testCurve), test.NoSerializationChecks(), test.WithBackends(backend.GROTH16, backend.PLONK))
 	}, testName[T]())
 }
+
+func TestMulBLS12381Fp(t *testing.T) {
+	testMulBLS12381Fp[BLS12381Fp](t)
+	testMulBLS12381Fp[emparams.BLS12381FpWide](t)
+}
+
+func testMulBLS12381Fp[T FieldParams](t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.Run(func(assert *test.Assert) {
+		var a, b, c fp_bls12381.Element
+		a.SetRandom()
+		b.SetRandom()
+		c.Mul(&a, &b)
+
+		var circuit MulNoOverflowCircuit[T]
+		witness := MulNoOverflowCircuit[T]{
+			A: ValueOf[T](a),
+			B: ValueOf[T](b),
+			C: ValueOf[T](c),
+		}
+		assert.ProverSucceeded(&circuit, &witness, test.WithCurves(testCurve), test.NoSerializationChecks(), test.WithBackends(backend.GROTH16, backend.PLONK))
+	}, testName[T]())
+}
+
+type mulChainCircuit[T FieldParams] struct {
+	A, B Element[T]
+	n    int
+}
+
+func (c *mulChainCircuit[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	res := &c.A
+	for i := 0; i < c.n; i++ {
+		res = f.Mul(res, &c.B)
+	}
+	f.AssertIsEqual(res, &c.A)
+	return nil
+}
+
+type reduceCircuit[T FieldParams] struct {
+	A, B Element[T]
+}
+
+func (c *reduceCircuit[T]) Define(api frontend.API) error {
+	f, err := NewField[T](api)
+	if err != nil {
+		return err
+	}
+	// the sum has overflow, so that Reduce is not a no-op
+	res := f.Reduce(f.Add(&c.A, &c.B))
+	f.AssertIsEqual(res, &c.A)
+	return nil
+}
+
+// benchmarkCircuit benchmarks separately the compilation of circuit, the
+// solving of assignment and the proving, with Groth16 and PLONK.
+func benchmarkCircuit(b *testing.B, circuit, assignment frontend.Circuit) {
+	w, err := frontend.NewWitness(assignment, testCurve.ScalarField())
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, builder := range []struct {
+		name    string
+		builder frontend.NewBuilder
+	}{{"groth16", r1cs.NewBuilder}, {"plonk", scs.NewBuilder}} {
+		var ccs constraint.ConstraintSystem
+		b.Run(builder.name+"/compile", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if ccs, err = frontend.Compile(testCurve.ScalarField(), builder.builder, circuit); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(ccs.GetNbConstraints()), "constraints")
+		})
+		if ccs == nil {
+			// the compilation was filtered out, compile once for the other
+			// benchmarks
+			if ccs, err = frontend.Compile(testCurve.ScalarField(), builder.builder, circuit); err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.Run(builder.name+"/solve", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := ccs.Solve(w); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		var prove func() error
+		if builder.name == "groth16" {
+			pk, _, err := groth16.Setup(ccs)
+			if err != nil {
+				b.Fatal(err)
+			}
+			prove = func() error { _, err := groth16.Prove(ccs, pk, w); return err }
+		} else {
+			srs, err := test.NewKZGSRS(ccs)
+			if err != nil {
+				b.Fatal(err)
+			}
+			pk, _, err := plonk.Setup(ccs, srs)
+			if err != nil {
+				b.Fatal(err)
+			}
+			prove = func() error { _, err := plonk.Prove(ccs, pk, w); return err }
+		}
+		b.Run(builder.name+"/prove", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if err := prove(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func benchmarkMulBLS12381Fp[T FieldParams](b *testing.B) {
+	// multiplying a by 1 gives back a
+	var a, x fp_bls12381.Element
+	a.SetRandom()
+	x.SetOne()
+	benchmarkCircuit(b, &mulChainCircuit[T]{n: 10}, &mulChainCircuit[T]{A: ValueOf[T](a), B: ValueOf[T](x)})
+}
+
+func BenchmarkMulBLS12381Fp(b *testing.B) {
+	b.Run(testName[BLS12381Fp](), benchmarkMulBLS12381Fp[BLS12381Fp])
+	b.Run(testName[emparams.BLS12381FpWide](), benchmarkMulBLS12381Fp[emparams.BLS12381FpWide])
+}
+
+func benchmarkReduceBLS12381Fp[T FieldParams](b *testing.B) {
+	var a fp_bls12381.Element
+	a.SetRandom()
+	benchmarkCircuit(b, &reduceCircuit[T]{}, &reduceCircuit[T]{A: ValueOf[T](a), B: ValueOf[T](0)})
+}
+
+func BenchmarkReduceBLS12381Fp(b *testing.B) {
+	b.Run(testName[BLS12381Fp](), benchmarkReduceBLS12381Fp[BLS12381Fp])
+	b.Run(testName[emparams.BLS12381FpWide](), benchmarkReduceBLS12381Fp[emparams.BLS12381FpWide])
+}
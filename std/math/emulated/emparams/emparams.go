@@ -130,6 +130,23 @@ type BLS12381Fp struct{ sixLimbPrimeField }
 
 func (fp BLS12381Fp) Modulus() *big.Int { return ecc.BLS12_381.BaseField() }
 
+// BLS12381FpWide provides type parametrization for field emulation:
+//   - limbs: 4
+//   - limb width: 96 bits
+//
+// The prime modulus for type parametrisation is the one of [BLS12381Fp], the
+// base field of the BLS12-381 curve. The wider limbs are tuned for native
+// fields of about 254 bits, such as the scalar field of BN254. There, compared
+// to [BLS12381Fp], they reduce the cost of the multiplications by about a
+// quarter with PLONK and an eighth with Groth16, while a single reduction costs
+// about a tenth more.
+type BLS12381FpWide struct{}
+
+func (fp BLS12381FpWide) NbLimbs() uint     { return 4 }
+func (fp BLS12381FpWide) BitsPerLimb() uint { return 96 }
+func (fp BLS12381FpWide) IsPrime() bool     { return true }
+func (fp BLS12381FpWide) Modulus() *big.Int { return ecc.BLS12_381.BaseField() }
+
 // BLS12381Fr provides type parametrization for field emulation:
 //   - limbs: 4
 //   - limb width: 64 bits
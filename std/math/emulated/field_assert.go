@@ -113,6 +113,24 @@ func (f *Field[T]) enforceWidth(a *Element[T], modWidth bool) {
 	}
 }
 
+// AssertLimbsInRange asserts that every limb of a is less than
+// 2^(BitsPerLimb+a.Overflow()), the range tracked by the overflow of a. The
+// elements returned by the [Field] methods are in range by construction: it is
+// meant to validate elements whose limbs were set by the caller, for example
+// from the outputs of a hint.
+func (f *Field[T]) AssertLimbsInRange(a *Element[T]) {
+	nbBits := int(f.fParams.BitsPerLimb() + a.overflow)
+	for i := range a.Limbs {
+		if c, ok := f.api.Compiler().ConstantValue(a.Limbs[i]); ok {
+			if c.BitLen() > nbBits {
+				panic(fmt.Sprintf("constant limb %d wider than %d bits", i, nbBits))
+			}
+			continue
+		}
+		f.checker.Check(a.Limbs[i], nbBits)
+	}
+}
+
 // AssertIsEqual ensures that a is equal to b modulo the modulus.
 func (f *Field[T]) AssertIsEqual(a, b *Element[T]) {
 	f.enforceWidthConditional(a)
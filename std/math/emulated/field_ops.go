@@ -239,6 +239,23 @@ func (f *Field[T]) Sub(a, b *Element[T]) *Element[T] {
 	return f.reduceAndOp(f.sub, f.subPreCond, a, b)
 }
 
+// AddNoReduce computes a+b and returns it. Contrary to [Field[T].Add], it never
+// reduces the inputs: the overflow of the result (see [Element[T].Overflow]) is
+// one more than the largest overflow of the inputs, and it panics if the limbs
+// of the result could overflow the native field. This lets the caller chain
+// additions and choose when to call [Field[T].Reduce]. Doesn't mutate inputs.
+func (f *Field[T]) AddNoReduce(a, b *Element[T]) *Element[T] {
+	return f.noReduceOp(f.add, f.addPreCond, a, b)
+}
+
+// SubNoReduce computes a-b and returns it. Contrary to [Field[T].Sub], it never
+// reduces the inputs: the overflow of the result is max(a.Overflow(),
+// b.Overflow()+1)+1, and it panics if the limbs of the result could overflow
+// the native field. Doesn't mutate inputs.
+func (f *Field[T]) SubNoReduce(a, b *Element[T]) *Element[T] {
+	return f.noReduceOp(f.sub, f.subPreCond, a, b)
+}
+
 // subReduce returns a-b and returns it. Contrary to [Field[T].Sub] method this
 // method does not reduce the inputs if the result would overflow. This method
 // is currently only used as a subroutine in [Field[T].Reduce] method to avoid
@@ -373,6 +390,16 @@ func (f *Field[T]) reduceAndOp(op func(*Element[T], *Element[T], uint) *Element[
 	return op(a, b, nextOverflow)
 }
 
+func (f *Field[T]) noReduceOp(op func(*Element[T], *Element[T], uint) *Element[T], preCond func(*Element[T], *Element[T]) (uint, error), a, b *Element[T]) *Element[T] {
+	f.enforceWidthConditional(a)
+	f.enforceWidthConditional(b)
+	nextOverflow, err := preCond(a, b)
+	if err != nil {
+		panic(fmt.Sprintf("%v: the inputs must be reduced first", err))
+	}
+	return op(a, b, nextOverflow)
+}
+
 type overflowError struct {
 	op           string
 	nextOverflow uint
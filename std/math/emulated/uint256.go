@@ -0,0 +1,46 @@
+package emulated
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// constantComparator is implemented by the builders which compare a binary
+// decomposition to a constant directly.
+type constantComparator interface {
+	MustBeLessOrEqCst(aBits []frontend.Variable, bound *big.Int, aForDebug frontend.Variable)
+}
+
+// AssertFitsInField asserts that the 256 bits unsigned integer hi·2¹²⁸ + lo,
+// given by its high and low 128 bits limbs, is a canonical element of the
+// native field, that is strictly smaller than its modulus. Both limbs are
+// asserted to fit in 128 bits. It typically checks a uint256 received from
+// the EVM before using hi·2¹²⁸ + lo as a native value.
+//
+// It panics if the native field is not larger than 2¹²⁸ or if it is larger than
+// 2²⁵⁶.
+func AssertFitsInField(api frontend.API, hi, lo frontend.Variable) {
+	const limbBits = 128
+	nbBits := api.Compiler().FieldBitLen()
+	if nbBits <= limbBits || nbBits > 2*limbBits {
+		panic("AssertFitsInField: the native field must have between 129 and 256 bits")
+	}
+	cmper, ok := api.Compiler().(constantComparator)
+	if !ok {
+		panic("builder does not expose comparison to constant")
+	}
+
+	loBits := bits.ToBinary(api, lo, bits.WithNbDigits(limbBits))
+	hiBits := bits.ToBinary(api, hi, bits.WithNbDigits(limbBits))
+
+	// the bits of hi over the field bit length must be zero, and the others are
+	// compared with lo to the largest canonical value.
+	for i := nbBits - limbBits; i < limbBits; i++ {
+		api.AssertIsEqual(hiBits[i], 0)
+	}
+	vBits := append(loBits, hiBits[:nbBits-limbBits]...)
+	bound := new(big.Int).Sub(api.Compiler().Field(), big.NewInt(1))
+	cmper.MustBeLessOrEqCst(vBits, bound, lo)
+}
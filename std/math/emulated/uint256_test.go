@@ -0,0 +1,55 @@
+package emulated
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type fitsInFieldCircuit struct {
+	Hi, Lo frontend.Variable
+}
+
+func (c *fitsInFieldCircuit) Define(api frontend.API) error {
+	AssertFitsInField(api, c.Hi, c.Lo)
+	return nil
+}
+
+func TestAssertFitsInField(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	modulus := ecc.BN254.ScalarField()
+	mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	split := func(v *big.Int) *fitsInFieldCircuit {
+		return &fitsInFieldCircuit{
+			Hi: new(big.Int).Rsh(v, 128),
+			Lo: new(big.Int).And(v, mask),
+		}
+	}
+
+	opts := []test.TestingOption{test.WithCurves(ecc.BN254)}
+	for _, v := range []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		mask,
+		new(big.Int).Sub(modulus, big.NewInt(1)),
+	} {
+		opts = append(opts, test.WithValidAssignment(split(v)))
+	}
+	for _, v := range []*big.Int{
+		modulus,
+		new(big.Int).Add(modulus, big.NewInt(1)),
+		new(big.Int).Lsh(big.NewInt(1), 254),
+		new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1)),
+	} {
+		opts = append(opts, test.WithInvalidAssignment(split(v)))
+	}
+	// limbs must fit in 128 bits, even when the value they encode is
+	// canonical.
+	opts = append(opts, test.WithInvalidAssignment(&fitsInFieldCircuit{Hi: 0, Lo: new(big.Int).Add(mask, big.NewInt(1))}))
+
+	assert.CheckCircuit(&fitsInFieldCircuit{}, opts...)
+}
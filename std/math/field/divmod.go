@@ -0,0 +1,63 @@
+package field
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all hint functions used in this package. This method is
+// useful for registering all hints in the solver.
+func GetHints() []solver.Hint {
+	return []solver.Hint{divModHint}
+}
+
+// DivMod returns the quotient q and the remainder r of the Euclidean division
+// of a by b, that is a = q·b + r with 0 ≤ r < b. a and b must be less than
+// 2^bitLen, and b is asserted to be. The circuit is not satisfiable when b is
+// zero. DivMod panics at compile time if 2·bitLen+1 is not less than the field
+// bit length, as q·b + r could then overflow the field.
+func DivMod(api frontend.API, a, b frontend.Variable, bitLen int) (q, r frontend.Variable) {
+	if bitLen <= 0 {
+		panic("DivMod: bitLen must be positive")
+	}
+	if 2*bitLen+1 >= api.Compiler().FieldBitLen() {
+		panic("DivMod: q·b + r may overflow the field")
+	}
+
+	res, err := api.Compiler().NewHint(divModHint, 2, a, b)
+	if err != nil {
+		panic(err)
+	}
+	q, r = res[0], res[1]
+
+	// q, r and b are less than 2^bitLen so that q·b + r < 2^(2·bitLen+1) does
+	// not wrap around, and the relation holds over the integers.
+	bits.ToBinary(api, q, bits.WithNbDigits(bitLen))
+	bits.ToBinary(api, r, bits.WithNbDigits(bitLen))
+	bits.ToBinary(api, b, bits.WithNbDigits(bitLen))
+	api.AssertIsEqual(a, api.Add(api.Mul(q, b), r))
+
+	// r < b, which also rules out b = 0 as b - r - 1 would wrap around.
+	bits.ToBinary(api, api.Sub(b, r, 1), bits.WithNbDigits(bitLen))
+
+	return q, r
+}
+
+// divModHint returns the quotient and the remainder of the Euclidean division
+// of inputs[0] by inputs[1], or 0 and 0 when inputs[1] is zero.
+func divModHint(_ *big.Int, inputs, results []*big.Int) error {
+	if inputs[1].Sign() == 0 {
+		results[0].SetUint64(0)
+		results[1].SetUint64(0)
+		return nil
+	}
+	results[0].DivMod(inputs[0], inputs[1], results[1])
+	return nil
+}
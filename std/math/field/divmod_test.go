@@ -0,0 +1,38 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type divModCircuit struct {
+	A, B frontend.Variable
+	Q, R frontend.Variable
+}
+
+func (c *divModCircuit) Define(api frontend.API) error {
+	q, r := DivMod(api, c.A, c.B, 8)
+	api.AssertIsEqual(q, c.Q)
+	api.AssertIsEqual(r, c.R)
+	return nil
+}
+
+func TestDivMod(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.CheckCircuit(&divModCircuit{},
+		test.WithValidAssignment(&divModCircuit{A: 17, B: 5, Q: 3, R: 2}),
+		test.WithValidAssignment(&divModCircuit{A: 15, B: 5, Q: 3, R: 0}),
+		test.WithValidAssignment(&divModCircuit{A: 4, B: 5, Q: 0, R: 4}),
+		test.WithValidAssignment(&divModCircuit{A: 0, B: 1, Q: 0, R: 0}),
+		test.WithValidAssignment(&divModCircuit{A: 255, B: 255, Q: 1, R: 0}),
+		test.WithValidAssignment(&divModCircuit{A: 255, B: 1, Q: 255, R: 0}),
+		test.WithInvalidAssignment(&divModCircuit{A: 17, B: 5, Q: 2, R: 7}),
+		test.WithInvalidAssignment(&divModCircuit{A: 17, B: 0, Q: 0, R: 0}),
+		test.WithInvalidAssignment(&divModCircuit{A: 0, B: 0, Q: 0, R: 0}),
+		test.WithInvalidAssignment(&divModCircuit{A: 256, B: 1, Q: 256, R: 0}),
+		test.WithInvalidAssignment(&divModCircuit{A: 17, B: 256, Q: 0, R: 17}),
+	)
+}
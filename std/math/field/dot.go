@@ -0,0 +1,33 @@
+package field
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Dot returns the dot product ∑ᵢ a[i]*b[i] of a and b in the native field. It
+// panics if a and b don't have the same length.
+func Dot(api frontend.API, a, b []frontend.Variable) frontend.Variable {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf("dot product of vectors of different lengths %d and %d", len(a), len(b)))
+	}
+	terms := make([]frontend.Variable, len(a))
+	for i := range a {
+		terms[i] = api.Mul(a[i], b[i])
+	}
+	return Sum(api, terms)
+}
+
+// DotConst returns the dot product ∑ᵢ a[i]*b[i] of a with the constant vector
+// b in the native field. The product with the constants is a linear
+// combination of the a[i], so it is cheaper than [Dot]. It panics if a and b
+// don't have the same length.
+func DotConst(api frontend.API, a []frontend.Variable, b []*big.Int) frontend.Variable {
+	bv := make([]frontend.Variable, len(b))
+	for i := range b {
+		bv[i] = b[i]
+	}
+	return Dot(api, a, bv)
+}
@@ -0,0 +1,65 @@
+package field
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+type dotCircuit struct {
+	A, B     [3]frontend.Variable
+	Expected frontend.Variable
+}
+
+func (c *dotCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(Dot(api, c.A[:], c.B[:]), c.Expected)
+	return nil
+}
+
+type dotConstCircuit struct {
+	A        [3]frontend.Variable
+	Expected frontend.Variable
+}
+
+func (c *dotConstCircuit) Define(api frontend.API) error {
+	b := []*big.Int{big.NewInt(2), big.NewInt(-1), big.NewInt(10)}
+	api.AssertIsEqual(DotConst(api, c.A[:], b), c.Expected)
+	return nil
+}
+
+func TestDot(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.CheckCircuit(&dotCircuit{},
+		test.WithValidAssignment(&dotCircuit{A: [3]frontend.Variable{1, 2, 3}, B: [3]frontend.Variable{4, 5, 6}, Expected: 32}),
+		test.WithValidAssignment(&dotCircuit{A: [3]frontend.Variable{0, 0, 0}, B: [3]frontend.Variable{4, 5, 6}, Expected: 0}),
+		test.WithInvalidAssignment(&dotCircuit{A: [3]frontend.Variable{1, 2, 3}, B: [3]frontend.Variable{4, 5, 6}, Expected: 33}),
+	)
+
+	assert.Panics(func() {
+		Dot(nil, make([]frontend.Variable, 2), make([]frontend.Variable, 3))
+	})
+}
+
+func TestDotConst(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.CheckCircuit(&dotConstCircuit{},
+		test.WithValidAssignment(&dotConstCircuit{A: [3]frontend.Variable{1, 2, 3}, Expected: 30}),
+		test.WithValidAssignment(&dotConstCircuit{A: [3]frontend.Variable{0, 10, 1}, Expected: 0}),
+		test.WithInvalidAssignment(&dotConstCircuit{A: [3]frontend.Variable{1, 2, 3}, Expected: 31}),
+	)
+
+	// the products with constants are folded into a single linear combination
+	ccs, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &dotConstCircuit{})
+	assert.NoError(err)
+	assert.Equal(1, ccs.GetNbConstraints())
+
+	assert.Panics(func() {
+		DotConst(nil, make([]frontend.Variable, 2), make([]*big.Int, 3))
+	})
+}
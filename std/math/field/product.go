@@ -0,0 +1,27 @@
+package field
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// GrandProduct returns the grand product ∏ᵢ num[i]/den[i] in the native field,
+// which is the last value of the accumulator zᵢ = zᵢ₋₁·num[i]/den[i] of the
+// permutation arguments. The numerators and the denominators are multiplied
+// separately and the result is obtained with a single inversion, which asserts
+// that all the denominators are non-zero. It panics if num and den don't have
+// the same length, and returns 1 when they are empty.
+func GrandProduct(api frontend.API, num, den []frontend.Variable) frontend.Variable {
+	if len(num) != len(den) {
+		panic(fmt.Sprintf("grand product of vectors of different lengths %d and %d", len(num), len(den)))
+	}
+	var n, d frontend.Variable = 1, 1
+	for i := range num {
+		n = api.Mul(n, num[i])
+		d = api.Mul(d, den[i])
+	}
+	// the inversion is unsatisfiable when d is zero, that is when any of the
+	// denominators is zero.
+	return api.Mul(n, api.Inverse(d))
+}
@@ -0,0 +1,56 @@
+package field
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type grandProductCircuit struct {
+	Num, Den [5]frontend.Variable
+	Expected frontend.Variable
+}
+
+func (c *grandProductCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(GrandProduct(api, c.Num[:], c.Den[:]), c.Expected)
+	return nil
+}
+
+func TestGrandProduct(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var valid, wrongResult, zeroDen grandProductCircuit
+	var z fr.Element
+	z.SetOne()
+	for i := range valid.Num {
+		var n, d fr.Element
+		n.SetRandom()
+		d.SetRandom()
+		valid.Num[i], valid.Den[i] = n.String(), d.String()
+		d.Inverse(&d)
+		z.Mul(&z, &n).Mul(&z, &d)
+	}
+	valid.Expected = z.String()
+
+	wrongResult = valid
+	var one fr.Element
+	one.SetOne()
+	wrongResult.Expected = z.Add(&z, &one).String()
+
+	zeroDen = valid
+	zeroDen.Num[2], zeroDen.Den[2] = 0, 0
+
+	assert.CheckCircuit(&grandProductCircuit{},
+		test.WithValidAssignment(&valid),
+		test.WithInvalidAssignment(&wrongResult),
+		test.WithInvalidAssignment(&zeroDen),
+		test.WithCurves(ecc.BN254),
+	)
+
+	assert.Panics(func() {
+		GrandProduct(nil, make([]frontend.Variable, 2), make([]frontend.Variable, 3))
+	})
+}
@@ -0,0 +1,49 @@
+// Package field provides gadgets for arithmetic over the native field which
+// keep track of the integer bounds of the values, to avoid overflows.
+package field
+
+import (
+	"fmt"
+	mbits "math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// Sum returns the sum of values in the native field. The result is reduced
+// modulo the field order, see [SumBounded] for a sum which can't wrap around.
+func Sum(api frontend.API, values []frontend.Variable) frontend.Variable {
+	switch len(values) {
+	case 0:
+		return 0
+	case 1:
+		return values[0]
+	default:
+		return api.Add(values[0], values[1], values[2:]...)
+	}
+}
+
+// SumBounded returns the sum of values, which are asserted to be less than
+// 2^bitLen. The bound on the values ensures that the sum is less than
+// 2^(bitLen+⌈log₂(n)⌉), where n is len(values), and so equals the integer sum
+// of the values when it doesn't overflow the field. SumBounded panics at
+// compile time if bitLen+⌈log₂(n)⌉ is not less than the field bit length.
+func SumBounded(api frontend.API, values []frontend.Variable, bitLen int) frontend.Variable {
+	if bitLen <= 0 {
+		panic("bit length must be positive")
+	}
+	if len(values) == 0 {
+		return 0
+	}
+	sumBitLen := bitLen + mbits.Len(uint(len(values)-1))
+	if sumBitLen >= api.Compiler().FieldBitLen() {
+		panic(fmt.Sprintf("the sum of %d values of %d bits may overflow the field of %d bits", len(values), bitLen, api.Compiler().FieldBitLen()))
+	}
+
+	// the values are decomposed into exactly bitLen bits, as the bound must be
+	// tight for the overflow check above to hold.
+	for i := range values {
+		bits.ToBinary(api, values[i], bits.WithNbDigits(bitLen))
+	}
+	return Sum(api, values)
+}
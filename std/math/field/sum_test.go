@@ -0,0 +1,59 @@
+package field
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type sumBoundedCircuit struct {
+	Values   []frontend.Variable
+	Expected frontend.Variable
+	bitLen   int
+}
+
+func (c *sumBoundedCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(SumBounded(api, c.Values, c.bitLen), c.Expected)
+	api.AssertIsEqual(Sum(api, c.Values), c.Expected)
+	return nil
+}
+
+func TestSumBounded(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.CheckCircuit(&sumBoundedCircuit{Values: make([]frontend.Variable, 4), bitLen: 32},
+		test.WithValidAssignment(&sumBoundedCircuit{Values: []frontend.Variable{1, 2, 3, 4}, Expected: 10}),
+		test.WithValidAssignment(&sumBoundedCircuit{Values: []frontend.Variable{0xffffffff, 0xffffffff, 0xffffffff, 0xffffffff}, Expected: 0x3fffffffc}),
+		test.WithInvalidAssignment(&sumBoundedCircuit{Values: []frontend.Variable{1 << 32, 0, 0, 0}, Expected: 1 << 32}),
+		test.WithInvalidAssignment(&sumBoundedCircuit{Values: []frontend.Variable{-1, 1, 0, 0}, Expected: 0}),
+	)
+}
+
+func TestSumBoundedOverflow(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+
+	// 1000 values of 243 bits sum to less than 2^253, which fits in the 254
+	// bits of the field.
+	const n, bitLen = 1000, 243
+	max := new(big.Int).Lsh(big.NewInt(1), bitLen)
+	max.Sub(max, big.NewInt(1))
+	witness := sumBoundedCircuit{Values: make([]frontend.Variable, n), Expected: new(big.Int).Mul(max, big.NewInt(n))}
+	for i := range witness.Values {
+		witness.Values[i] = max
+	}
+	assert.NoError(test.IsSolved(&sumBoundedCircuit{Values: make([]frontend.Variable, n), bitLen: bitLen}, &witness, field))
+
+	// the values must be in range
+	invalid := witness
+	invalid.Values = append([]frontend.Variable{new(big.Int).Add(max, big.NewInt(1))}, witness.Values[1:]...)
+	invalid.Expected = new(big.Int).Add(witness.Expected.(*big.Int), big.NewInt(1))
+	assert.Error(test.IsSolved(&sumBoundedCircuit{Values: make([]frontend.Variable, n), bitLen: bitLen}, &invalid, field))
+
+	// with one more bit the sum may overflow, which is rejected when
+	// building the circuit.
+	assert.Error(test.IsSolved(&sumBoundedCircuit{Values: make([]frontend.Variable, n), bitLen: bitLen + 1}, &witness, field))
+}
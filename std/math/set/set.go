@@ -0,0 +1,38 @@
+// Package set implements gadgets over multisets of variables.
+package set
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/multicommit"
+)
+
+// AssertIsPermutation asserts that b is a permutation of a, that is that they
+// are equal as multisets. It panics if the lists have different lengths, as no
+// assignment could then satisfy the assertion.
+//
+// The lists are committed to, and the commitment is used as a random challenge
+// r to check the grand products ∏ᵢ (r - a[i]) == ∏ᵢ (r - b[i]). The soundness
+// error is at most len(a)/|F|, where F is the native field. The builder must
+// support commitments (see [frontend.Committer]).
+func AssertIsPermutation(api frontend.API, a, b []frontend.Variable) {
+	if len(a) != len(b) {
+		panic(fmt.Sprintf("lists of different lengths %d and %d can't be permutations", len(a), len(b)))
+	}
+	if len(a) == 0 {
+		return
+	}
+	toCommit := make([]frontend.Variable, 0, len(a)+len(b))
+	toCommit = append(toCommit, a...)
+	toCommit = append(toCommit, b...)
+	multicommit.WithCommitment(api, func(api frontend.API, r frontend.Variable) error {
+		var prodA, prodB frontend.Variable = 1, 1
+		for i := range a {
+			prodA = api.Mul(prodA, api.Sub(r, a[i]))
+			prodB = api.Mul(prodB, api.Sub(r, b[i]))
+		}
+		api.AssertIsEqual(prodA, prodB)
+		return nil
+	}, toCommit...)
+}
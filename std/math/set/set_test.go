@@ -0,0 +1,48 @@
+package set
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/test"
+)
+
+type permutationCircuit struct {
+	A, B []frontend.Variable
+}
+
+func (c *permutationCircuit) Define(api frontend.API) error {
+	AssertIsPermutation(api, c.A, c.B)
+	return nil
+}
+
+func TestAssertIsPermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	circuit := &permutationCircuit{A: make([]frontend.Variable, 5), B: make([]frontend.Variable, 5)}
+	assert.CheckCircuit(circuit,
+		test.WithValidAssignment(&permutationCircuit{
+			A: []frontend.Variable{1, 2, 3, 2, 5},
+			B: []frontend.Variable{2, 5, 1, 3, 2},
+		}),
+		test.WithValidAssignment(&permutationCircuit{
+			A: []frontend.Variable{7, 7, 7, 7, 7},
+			B: []frontend.Variable{7, 7, 7, 7, 7},
+		}),
+		// same elements, different multiplicities
+		test.WithInvalidAssignment(&permutationCircuit{
+			A: []frontend.Variable{1, 2, 3, 2, 5},
+			B: []frontend.Variable{2, 5, 1, 3, 3},
+		}),
+		test.WithInvalidAssignment(&permutationCircuit{
+			A: []frontend.Variable{1, 2, 3, 4, 5},
+			B: []frontend.Variable{1, 2, 3, 4, 6},
+		}),
+		test.WithCurves(ecc.BN254),
+	)
+
+	_, err := frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &permutationCircuit{A: make([]frontend.Variable, 2), B: make([]frontend.Variable, 3)})
+	assert.Error(err, "lists of different lengths should be rejected")
+}
@@ -0,0 +1,63 @@
+// Package signed implements signed integer arithmetic in two's complement.
+//
+// A signed integer of bitLen bits is represented by the unsigned integer in
+// [0, 2^bitLen) with the same bits: x ≥ 0 is represented by x and x < 0 by
+// 2^bitLen + x. Addition and multiplication are then the unsigned ones modulo
+// 2^bitLen, and wrap around on overflow as the fixed-size integers of most
+// instruction sets do.
+//
+// bitLen must be small enough for the products of two values to fit in the
+// native field, that is 2·bitLen must be less than the bit length of the
+// modulus.
+package signed
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// AssertIsNeg asserts that negA is the two's complement negation of a in
+// bitLen bits, that is a + negA ≡ 0 mod 2^bitLen. Both are asserted to be in
+// [0, 2^bitLen), so that their sum is either 0 (when a is 0) or 2^bitLen.
+//
+// As in two's complement, the negation of the smallest integer -2^(bitLen-1) is
+// itself.
+func AssertIsNeg(api frontend.API, a, negA frontend.Variable, bitLen int) {
+	rc := rangecheck.New(api)
+	rc.Check(a, bitLen)
+	rc.Check(negA, bitLen)
+
+	bound := new(big.Int).Lsh(big.NewInt(1), uint(bitLen))
+	sum := api.Add(a, negA)
+	api.AssertIsEqual(api.Mul(sum, api.Sub(sum, bound)), 0)
+}
+
+// AddSigned returns a + b mod 2^bitLen, the two's complement sum of a and b in
+// bitLen bits. a and b are asserted to be in [0, 2^bitLen).
+func AddSigned(api frontend.API, a, b frontend.Variable, bitLen int) frontend.Variable {
+	rc := rangecheck.New(api)
+	rc.Check(a, bitLen)
+	rc.Check(b, bitLen)
+
+	// the sum is on bitLen+1 bits, the carry is dropped
+	sumBits := bits.ToBinary(api, api.Add(a, b), bits.WithNbDigits(bitLen+1))
+	return bits.FromBinary(api, sumBits[:bitLen])
+}
+
+// MulSigned returns a · b mod 2^bitLen, the two's complement product of a and b
+// in bitLen bits. a and b are asserted to be in [0, 2^bitLen).
+func MulSigned(api frontend.API, a, b frontend.Variable, bitLen int) frontend.Variable {
+	if 2*bitLen >= api.Compiler().FieldBitLen() {
+		panic("bit length too large for the product to fit in the native field")
+	}
+	rc := rangecheck.New(api)
+	rc.Check(a, bitLen)
+	rc.Check(b, bitLen)
+
+	// the product is on 2·bitLen bits, the high half is dropped
+	prodBits := bits.ToBinary(api, api.Mul(a, b), bits.WithNbDigits(2*bitLen))
+	return bits.FromBinary(api, prodBits[:bitLen])
+}
@@ -0,0 +1,73 @@
+package signed
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+// two's complement representation of v in 8 bits
+func i8(v int8) frontend.Variable {
+	return uint8(v)
+}
+
+type negCircuit struct {
+	A, NegA frontend.Variable
+}
+
+func (c *negCircuit) Define(api frontend.API) error {
+	AssertIsNeg(api, c.A, c.NegA, 8)
+	return nil
+}
+
+func TestAssertIsNeg(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	assert.CheckCircuit(&negCircuit{},
+		test.WithValidAssignment(&negCircuit{A: i8(5), NegA: i8(-5)}),
+		test.WithValidAssignment(&negCircuit{A: i8(-5), NegA: i8(5)}),
+		test.WithValidAssignment(&negCircuit{A: 0, NegA: 0}),
+		test.WithValidAssignment(&negCircuit{A: i8(127), NegA: i8(-127)}),
+		test.WithValidAssignment(&negCircuit{A: i8(-128), NegA: i8(-128)}),
+		test.WithInvalidAssignment(&negCircuit{A: i8(5), NegA: i8(-4)}),
+		test.WithInvalidAssignment(&negCircuit{A: i8(5), NegA: i8(5)}),
+		test.WithInvalidAssignment(&negCircuit{A: 0, NegA: 256}),
+		test.WithInvalidAssignment(&negCircuit{A: 261, NegA: -5}),
+		test.WithCurves(ecc.BN254),
+	)
+}
+
+type arithCircuit struct {
+	A, B, Sum, Prod frontend.Variable
+}
+
+func (c *arithCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(AddSigned(api, c.A, c.B, 8), c.Sum)
+	api.AssertIsEqual(MulSigned(api, c.A, c.B, 8), c.Prod)
+	return nil
+}
+
+func TestArithmetic(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	valid := func(a, b int8) test.TestingOption {
+		return test.WithValidAssignment(&arithCircuit{A: i8(a), B: i8(b), Sum: i8(a + b), Prod: i8(a * b)})
+	}
+	assert.CheckCircuit(&arithCircuit{},
+		valid(3, 4),
+		valid(-3, 4),
+		valid(3, -4),
+		valid(-3, -4),
+		valid(0, -7),
+		valid(127, 1),   // wraps to -128
+		valid(-128, -1), // wraps to -128
+		valid(100, 100), // product wraps
+		test.WithInvalidAssignment(&arithCircuit{A: i8(-3), B: i8(4), Sum: 2, Prod: i8(-12)}),
+		test.WithInvalidAssignment(&arithCircuit{A: i8(-3), B: i8(4), Sum: 1, Prod: 12}),
+		test.WithInvalidAssignment(&arithCircuit{A: i8(-3), B: i8(4), Sum: 257, Prod: i8(-12)}),
+		test.WithInvalidAssignment(&arithCircuit{A: 256, B: 0, Sum: 0, Prod: 0}),
+		test.WithCurves(ecc.BN254),
+	)
+}
@@ -0,0 +1,28 @@
+// Package nullifier provides functions to derive and check nullifiers in-circuit.
+//
+// A nullifier is computed as H(secret, index), where H is a SNARK-friendly hash
+// function, typically MiMC. The secret is hashed first, then the index, each of
+// them as one field element. Natively, with gnark-crypto's MiMC, this
+// corresponds to hashing the concatenation of the big-endian encodings of the
+// secret and of the index, each padded to the size of a field element.
+package nullifier
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// Derive returns the nullifier H(secret, index). The hasher h is reset
+// before and after use.
+func Derive(api frontend.API, h hash.FieldHasher, secret, index frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(secret, index)
+	res := h.Sum()
+	h.Reset()
+	return res
+}
+
+// AssertValid asserts that nullifier equals H(secret, index).
+func AssertValid(api frontend.API, h hash.FieldHasher, nullifier, secret, index frontend.Variable) {
+	api.AssertIsEqual(nullifier, Derive(api, h, secret, index))
+}
@@ -0,0 +1,54 @@
+package nullifier
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/consensys/gnark/frontend"
+	stdmimc "github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type nullifierCircuit struct {
+	Secret    frontend.Variable
+	Index     frontend.Variable `gnark:",public"`
+	Nullifier frontend.Variable `gnark:",public"`
+}
+
+func (c *nullifierCircuit) Define(api frontend.API) error {
+	h, err := stdmimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	AssertValid(api, &h, c.Nullifier, c.Secret, c.Index)
+	return nil
+}
+
+func TestNullifier(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var secret, index fr.Element
+	_, err := secret.SetRandom()
+	assert.NoError(err)
+	index.SetUint64(42)
+
+	// native derivation
+	h := mimc.NewMiMC()
+	secretBytes, indexBytes := secret.Bytes(), index.Bytes()
+	h.Write(secretBytes[:])
+	h.Write(indexBytes[:])
+	nullifier := h.Sum(nil)
+
+	valid := nullifierCircuit{Secret: secret.String(), Index: 42, Nullifier: nullifier}
+	swapped := nullifierCircuit{Secret: 42, Index: secret.String(), Nullifier: nullifier}
+	wrong := nullifierCircuit{Secret: secret.String(), Index: 43, Nullifier: new(big.Int).SetBytes(nullifier)}
+
+	assert.CheckCircuit(&nullifierCircuit{},
+		test.WithValidAssignment(&valid),
+		test.WithInvalidAssignment(&swapped),
+		test.WithInvalidAssignment(&wrong),
+		test.WithCurves(ecc.BN254))
+}
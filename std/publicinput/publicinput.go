@@ -0,0 +1,77 @@
+// Package publicinput provides helpers to bind many logical inputs of a
+// circuit to few public inputs.
+//
+// The cost of verifying a proof grows with the number of public inputs, which
+// on-chain translates into gas for the calldata and the multi-scalar
+// multiplication. When a circuit has many logical inputs, they can instead be
+// committed to a MiMC Merkle root, which is the single public input of the
+// circuit:
+//
+//	type Circuit struct {
+//		Root   frontend.Variable `gnark:",public"`
+//		Inputs []frontend.Variable
+//	}
+//
+//	func (c *Circuit) Define(api frontend.API) error {
+//		h, err := mimc.NewMiMC(api)
+//		if err != nil {
+//			return err
+//		}
+//		api.AssertIsEqual(publicinput.BindRoot(api, h, c.Inputs), c.Root)
+//		// ... constraints on c.Inputs
+//		return nil
+//	}
+//
+// The inputs are secret for the proof system: the verifier computes the root
+// of the inputs it expects, with the Merkle tree of
+// github.com/consensys/gnark-crypto/accumulator/merkletree and the native
+// MiMC, and verifies the proof against the root alone. The inputs themselves
+// can be published off-chain, for example in the calldata of another
+// transaction or in a blob.
+package publicinput
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+)
+
+type subtree struct {
+	height int
+	sum    frontend.Variable
+}
+
+// BindRoot returns the MiMC Merkle root of inputs, where a leaf is the hash of
+// an input and a node is the hash of its two children. When the number of
+// inputs is not a power of two, the tree is built as in merkletree.Tree of
+// gnark-crypto: the inputs are split into perfect subtrees of decreasing
+// sizes, which are then joined from the smallest to the largest. It panics if
+// inputs is empty. h is used as a fresh hasher: data written to it before is
+// ignored.
+func BindRoot(api frontend.API, h mimc.MiMC, inputs []frontend.Variable) frontend.Variable {
+	if len(inputs) == 0 {
+		panic("no inputs to bind")
+	}
+	var stack []subtree
+	for _, input := range inputs {
+		h.Reset()
+		h.Write(input)
+		s := subtree{sum: h.Sum()}
+		for len(stack) > 0 && stack[len(stack)-1].height == s.height {
+			s = subtree{height: s.height + 1, sum: nodeSum(h, stack[len(stack)-1].sum, s.sum)}
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, s)
+	}
+
+	root := stack[len(stack)-1].sum
+	for i := len(stack) - 2; i >= 0; i-- {
+		root = nodeSum(h, stack[i].sum, root)
+	}
+	return root
+}
+
+func nodeSum(h mimc.MiMC, a, b frontend.Variable) frontend.Variable {
+	h.Reset()
+	h.Write(a, b)
+	return h.Sum()
+}
@@ -0,0 +1,63 @@
+package publicinput
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type bindRootCircuit struct {
+	Root   frontend.Variable `gnark:",public"`
+	Inputs []frontend.Variable
+}
+
+func (c *bindRootCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	api.AssertIsEqual(BindRoot(api, h, c.Inputs), c.Root)
+	return nil
+}
+
+func TestBindRoot(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	for _, n := range []int{1, 5, 8} {
+		// native root
+		tree := merkletree.New(hash.MIMC_BN254.New())
+		inputs := make([]frontend.Variable, n)
+		for i := range inputs {
+			v, err := rand.Int(rand.Reader, fr.Modulus())
+			assert.NoError(err)
+			inputs[i] = v
+			b := make([]byte, fr.Bytes)
+			tree.Push(v.FillBytes(b))
+		}
+		root := tree.Root()
+
+		// the root is the only public input
+		valid := &bindRootCircuit{Root: root, Inputs: inputs}
+		publicWitness, err := frontend.NewWitness(valid, ecc.BN254.ScalarField(), frontend.PublicOnly())
+		assert.NoError(err)
+		assert.Equal(1, publicWitness.Vector().(fr.Vector).Len())
+
+		tampered := &bindRootCircuit{Root: root, Inputs: append([]frontend.Variable{}, inputs...)}
+		tampered.Inputs[n-1] = new(big.Int).Add(inputs[n-1].(*big.Int), big.NewInt(1))
+		assert.CheckCircuit(&bindRootCircuit{Inputs: make([]frontend.Variable, n)},
+			test.WithValidAssignment(valid),
+			test.WithInvalidAssignment(tampered),
+			test.WithCurves(ecc.BN254),
+			test.WithBackends(backend.GROTH16, backend.PLONK),
+		)
+	}
+}
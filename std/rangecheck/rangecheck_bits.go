@@ -0,0 +1,83 @@
+package rangecheck
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// Checker compares a variable to constant bounds. It decomposes the variable
+// into bits once, when created, and reuses the bits for every comparison, so
+// that a variable checked against several bounds is decomposed only once.
+type Checker struct {
+	api  frontend.API
+	bits []frontend.Variable // little endian
+}
+
+// NewChecker returns a Checker for v, which it decomposes into nbBits bits.
+// This asserts that v < 2ⁿᵇᴮⁱᵗˢ.
+func NewChecker(api frontend.API, v frontend.Variable, nbBits int) *Checker {
+	return &Checker{
+		api:  api,
+		bits: bits.ToBinary(api, v, bits.WithNbDigits(nbBits)),
+	}
+}
+
+// IsBit returns the i-th bit of the variable, starting from the least
+// significant one. It adds no constraint.
+func (c *Checker) IsBit(i int) frontend.Variable {
+	return c.bits[i]
+}
+
+// LessThan returns 1 if the variable is less than bound, and 0 otherwise.
+func (c *Checker) LessThan(bound *big.Int) frontend.Variable {
+	if bound.Sign() <= 0 {
+		return 0
+	}
+	if bound.BitLen() > len(c.bits) {
+		return 1
+	}
+	// from the most significant bit, eq is 1 while the bits of the variable
+	// equal the ones of bound, and lt becomes 1 at the first bit where the
+	// variable has a 0 and bound a 1. The bits are processed by runs of equal
+	// bits of bound, so that a run costs a single multiplication by eq.
+	lt := frontend.Variable(0)
+	eq := frontend.Variable(1)
+	for i := len(c.bits) - 1; i >= 0; {
+		bit := bound.Bit(i)
+		j := i
+		for j >= 0 && bound.Bit(j) == bit {
+			j--
+		}
+		same := c.runEquals(c.bits[j+1:i+1], bit)
+		eqAndSame := c.api.Mul(eq, same)
+		if bit == 1 {
+			lt = c.api.Add(lt, c.api.Sub(eq, eqAndSame))
+		}
+		eq = eqAndSame
+		i = j
+	}
+	return lt
+}
+
+// runEquals returns 1 if all the bits of run equal bit, and 0 otherwise.
+func (c *Checker) runEquals(run []frontend.Variable, bit uint) frontend.Variable {
+	if len(run) == 1 {
+		if bit == 1 {
+			return run[0]
+		}
+		return c.api.Sub(1, run[0])
+	}
+	// number of bits of run which differ from bit
+	nbDiff := c.api.Add(run[0], run[1], run[2:]...)
+	if bit == 1 {
+		nbDiff = c.api.Sub(len(run), nbDiff)
+	}
+	return c.api.IsZero(nbDiff)
+}
+
+// InRange returns 1 if the variable is in [lo, hi), and 0 otherwise.
+func (c *Checker) InRange(lo, hi *big.Int) frontend.Variable {
+	return c.api.Mul(c.LessThan(hi), c.api.Sub(1, c.LessThan(lo)))
+}
@@ -0,0 +1,95 @@
+package rangecheck
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/frontend/cs/scs"
+	"github.com/consensys/gnark/test"
+)
+
+type checkerCircuit struct {
+	V                   frontend.Variable
+	LessThan10, InRange frontend.Variable
+	Bit2                frontend.Variable
+}
+
+func (c *checkerCircuit) Define(api frontend.API) error {
+	checker := NewChecker(api, c.V, 8)
+	api.AssertIsEqual(checker.LessThan(big.NewInt(10)), c.LessThan10)
+	api.AssertIsEqual(checker.InRange(big.NewInt(5), big.NewInt(200)), c.InRange)
+	api.AssertIsEqual(checker.IsBit(2), c.Bit2)
+	// bounds out of the range of the decomposition
+	api.AssertIsEqual(checker.LessThan(big.NewInt(0)), 0)
+	api.AssertIsEqual(checker.LessThan(big.NewInt(256)), 1)
+	return nil
+}
+
+func TestChecker(t *testing.T) {
+	assert := test.NewAssert(t)
+	for v := 0; v < 256; v++ {
+		assignment := checkerCircuit{
+			V:          v,
+			LessThan10: 0,
+			InRange:    0,
+			Bit2:       (v >> 2) & 1,
+		}
+		if v < 10 {
+			assignment.LessThan10 = 1
+		}
+		if v >= 5 && v < 200 {
+			assignment.InRange = 1
+		}
+		assert.NoError(test.IsSolved(&checkerCircuit{}, &assignment, ecc.BN254.ScalarField()), v)
+	}
+	assert.Error(test.IsSolved(&checkerCircuit{}, &checkerCircuit{V: 256, LessThan10: 0, InRange: 0, Bit2: 0}, ecc.BN254.ScalarField()))
+}
+
+type checkerCountCircuit struct {
+	V        frontend.Variable
+	nbChecks int
+}
+
+func (c *checkerCountCircuit) Define(api frontend.API) error {
+	checker := NewChecker(api, c.V, 64)
+	// the cost of a comparison depends on the bound, which is the same for
+	// all the checks
+	for i := 0; i < c.nbChecks; i++ {
+		api.AssertIsEqual(checker.LessThan(big.NewInt(1000)), 1)
+	}
+	return nil
+}
+
+func TestCheckerConstraintCount(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, tc := range []struct {
+		builder frontend.NewBuilder
+		plonk   bool
+	}{{r1cs.NewBuilder, false}, {scs.NewBuilder, true}} {
+		builder := tc.builder
+		nbConstraints := func(nbChecks int) int {
+			ccs, err := frontend.Compile(ecc.BN254.ScalarField(), builder, &checkerCountCircuit{nbChecks: nbChecks})
+			assert.NoError(err)
+			return ccs.GetNbConstraints()
+		}
+		decomposition := nbConstraints(0)
+		check := nbConstraints(1) - decomposition
+		// the decomposition is shared by all the checks, each of which costs
+		// less than the decomposition
+		assert.Less(check, decomposition)
+		// the PLONK builder also shares the sums of bits of the identical
+		// comparisons, so that the next ones are cheaper
+		next := nbConstraints(2) - nbConstraints(1)
+		if tc.plonk {
+			assert.Less(next, check)
+		} else {
+			assert.Equal(check, next)
+		}
+		for _, k := range []int{4, 8} {
+			assert.Equal(decomposition+check+(k-1)*next, nbConstraints(k), k)
+		}
+	}
+}
@@ -86,6 +86,12 @@ func (c *commitChecker) commit(api frontend.API) error {
 		}
 		// store all limbs for counting
 		decomposed = append(decomposed, limbs...)
+		if r := c.collected[i].bits % baseLength; r != 0 {
+			// the most significant limb must be on r bits only. As it is
+			// checked to be on baseLength bits, shifting it by baseLength-r
+			// doesn't wrap around and the shifted value is checked again.
+			decomposed = append(decomposed, api.Mul(limbs[len(limbs)-1], 1<<(baseLength-r)))
+		}
 		// check that limbs are correct. We check the sizes of the limbs later
 		var composed frontend.Variable = 0
 		for j := range limbs {
@@ -156,6 +162,9 @@ func nbR1CSConstraints(baseLength int, collected []checkedVariable) int {
 	nbDecomposed := 0
 	for i := range collected {
 		nbDecomposed += int(decompSize(collected[i].bits, baseLength))
+		if collected[i].bits%baseLength != 0 {
+			nbDecomposed++ // the shifted most significant limb
+		}
 	}
 	eqs := len(collected)       // correctness of decomposition
 	nbRight := nbDecomposed     // inverse per decomposed
@@ -167,6 +176,9 @@ func nbPLONKConstraints(baseLength int, collected []checkedVariable) int {
 	nbDecomposed := 0
 	for i := range collected {
 		nbDecomposed += int(decompSize(collected[i].bits, baseLength))
+		if collected[i].bits%baseLength != 0 {
+			nbDecomposed++ // the shifted most significant limb
+		}
 	}
 	eqs := nbDecomposed               // check correctness of every decomposition. this is nbDecomp adds + eq cost per collected
 	nbRight := 3 * nbDecomposed       // denominator sub, inv and large sum per table entry
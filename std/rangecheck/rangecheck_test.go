@@ -44,3 +44,30 @@ func TestCheck(t *testing.T) {
 	_, err = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit, frontend.WithCompressThreshold(100))
 	assert.NoError(err)
 }
+
+type partialLimbCircuit struct {
+	A, B frontend.Variable
+}
+
+func (c *partialLimbCircuit) Define(api frontend.API) error {
+	r := newCommitRangechecker(api)
+	// the bit length isn't a multiple of the limb width chosen for the checks
+	for i := 0; i < 4; i++ {
+		r.Check(c.A, 8)
+		r.Check(c.B, 8)
+	}
+	return nil
+}
+
+// TestCheckPartialLimb checks values between 2^bits and the next multiple of
+// the limb width, which were accepted when the most significant limb was only
+// checked on the full limb width.
+func TestCheckPartialLimb(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&partialLimbCircuit{},
+		test.WithValidAssignment(&partialLimbCircuit{A: 255, B: 0}),
+		test.WithInvalidAssignment(&partialLimbCircuit{A: 256, B: 0}),
+		test.WithInvalidAssignment(&partialLimbCircuit{A: 0, B: 511}),
+		test.WithCurves(ecc.BN254),
+	)
+}
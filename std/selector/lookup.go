@@ -0,0 +1,74 @@
+package selector
+
+import (
+	"math/big"
+	mbits "math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+)
+
+// LookupConst returns table[index] for a table of constants. index must be
+// between 0 and len(table) - 1 (inclusive), otherwise the proof will fail.
+//
+// The index is decomposed into k bits. For each of the chunks of the table
+// sharing the same most significant bits of the index, the entries are
+// interpolated as a multilinear polynomial in the ⌈k/2⌉ least significant
+// bits, whose monomials are shared by all the chunks. The chunks are then
+// selected with a binary multiplexer on the remaining bits. As the
+// coefficients are constants, this costs about 2^⌈k/2⌉ + 2^⌊k/2⌋ R1CS
+// constraints on top of the decomposition, against about 2^(k-1) for Mux.
+func LookupConst(api frontend.API, index frontend.Variable, table []*big.Int) frontend.Variable {
+	if len(table) == 0 {
+		panic("empty table")
+	}
+	k := mbits.Len(uint(len(table) - 1))
+	if len(table) != 1<<k {
+		api.AssertIsLessOrEqual(index, len(table)-1)
+	}
+	if k == 0 {
+		api.AssertIsEqual(index, 0)
+		return table[0]
+	}
+	indexBits := bits.ToBinary(api, index, bits.WithNbDigits(k))
+	m := (k + 1) / 2
+	lowBits, highBits := indexBits[:m], indexBits[m:]
+
+	// monomials[s] is the product of the bits of lowBits in the subset s
+	monomials := make([]frontend.Variable, 1<<m)
+	monomials[0] = 1
+	for i := range lowBits {
+		for s := 0; s < 1<<i; s++ {
+			monomials[s|1<<i] = api.Mul(monomials[s], lowBits[i])
+		}
+	}
+
+	chunks := make([]frontend.Variable, (len(table)+len(monomials)-1)/len(monomials))
+	coeffs := make([]*big.Int, len(monomials))
+	for c := range chunks {
+		// the coefficients of the multilinear interpolation are obtained with
+		// the Möbius transform of the entries. Missing entries are set to 0.
+		for j := range coeffs {
+			coeffs[j] = new(big.Int)
+			if idx := c*len(coeffs) + j; idx < len(table) {
+				coeffs[j].Set(table[idx])
+			}
+		}
+		for i := 0; i < m; i++ {
+			for s := range coeffs {
+				if s&(1<<i) != 0 {
+					coeffs[s].Sub(coeffs[s], coeffs[s^1<<i])
+				}
+			}
+		}
+		var res frontend.Variable = 0
+		for s := range coeffs {
+			if coeffs[s].Sign() != 0 {
+				res = api.Add(res, api.Mul(coeffs[s], monomials[s]))
+			}
+		}
+		chunks[c] = res
+	}
+
+	return binaryMuxRecursive(api, highBits, chunks)
+}
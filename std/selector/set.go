@@ -0,0 +1,19 @@
+package selector
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// AssertIsInSet asserts that v is equal to one of the constants in allowed,
+// by constraining ∏ᵢ (v - allowed[i]) == 0. Duplicate values are allowed. If
+// allowed is empty, the product is 1 and no value of v satisfies the
+// constraint.
+func AssertIsInSet(api frontend.API, v frontend.Variable, allowed []*big.Int) {
+	prod := frontend.Variable(1)
+	for _, a := range allowed {
+		prod = api.Mul(prod, api.Sub(v, a))
+	}
+	api.AssertIsEqual(prod, 0)
+}
@@ -0,0 +1,36 @@
+package selector
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type isInSetCircuit struct {
+	V       frontend.Variable
+	allowed []*big.Int
+}
+
+func (c *isInSetCircuit) Define(api frontend.API) error {
+	AssertIsInSet(api, c.V, c.allowed)
+	return nil
+}
+
+func TestAssertIsInSet(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	allowed := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(2)}
+	assert.CheckCircuit(&isInSetCircuit{allowed: allowed},
+		test.WithValidAssignment(&isInSetCircuit{V: 0}),
+		test.WithValidAssignment(&isInSetCircuit{V: 2}),
+		test.WithValidAssignment(&isInSetCircuit{V: 3}),
+		test.WithInvalidAssignment(&isInSetCircuit{V: 4}),
+		test.WithInvalidAssignment(&isInSetCircuit{V: -1}),
+	)
+
+	// no value is in the empty set
+	assert.Error(test.IsSolved(&isInSetCircuit{}, &isInSetCircuit{V: 0}, ecc.BN254.ScalarField()))
+}
@@ -0,0 +1,49 @@
+/*
+Package bls implements BLS signature verification over BLS12-381.
+
+The signatures are in the minimal-public-key-size variant used by the Ethereum
+consensus layer: the public keys are in G1 and the signatures and the hashes of
+the messages in G2. The package depends on the [emulated/sw_bls12381] package
+for the pairing computations using non-native arithmetic, so that the
+signatures can be verified in a circuit over any native field.
+
+See [BLS] for the signature scheme.
+
+[BLS]: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+*/
+package bls
+
+import (
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+)
+
+// Verify asserts that sig is a valid signature of the message msg for the
+// public key pub, that is e(pub, msg) == e(g₁, sig) where g₁ is the generator
+// of G1. The equality is checked with a single product of two pairings.
+//
+// msg is the hash of the message to G2, which is not computed in-circuit. sig
+// is asserted to be in G2. pub and msg are assumed to be in G1 and G2, as is
+// the case for public keys validated on registration and for hashes to the
+// curve.
+func Verify(api frontend.API, pub *sw_bls12381.G1Affine, sig, msg *sw_bls12381.G2Affine) error {
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		return fmt.Errorf("new pairing: %w", err)
+	}
+	pairing.AssertIsOnG2(sig)
+
+	_, _, g1, _ := bls12381.Generators()
+	var g1Neg bls12381.G1Affine
+	g1Neg.Neg(&g1)
+	negGen := sw_bls12381.NewG1Affine(g1Neg)
+
+	// e(-g₁, sig) · e(pub, msg) == 1
+	if err := pairing.PairingCheck([]*sw_bls12381.G1Affine{&negGen, pub}, []*sw_bls12381.G2Affine{sig, msg}); err != nil {
+		return fmt.Errorf("pairing check: %w", err)
+	}
+	return nil
+}
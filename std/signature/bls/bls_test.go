@@ -0,0 +1,63 @@
+package bls
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/test"
+)
+
+// dst is the domain separation tag of the Ethereum consensus signatures.
+var dst = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+type verifyCircuit struct {
+	PubKey    sw_bls12381.G1Affine
+	Signature sw_bls12381.G2Affine
+	Message   sw_bls12381.G2Affine
+}
+
+func (c *verifyCircuit) Define(api frontend.API) error {
+	return Verify(api, &c.PubKey, &c.Signature, &c.Message)
+}
+
+func TestVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	sk, err := rand.Int(rand.Reader, fr.Modulus())
+	assert.NoError(err)
+	_, _, g1, _ := bls12381.Generators()
+	var pub bls12381.G1Affine
+	pub.ScalarMultiplication(&g1, sk)
+
+	msg, err := bls12381.HashToG2([]byte("message"), dst)
+	assert.NoError(err)
+	var sig bls12381.G2Affine
+	sig.ScalarMultiplication(&msg, sk)
+
+	// native verification
+	var g1Neg bls12381.G1Affine
+	g1Neg.Neg(&g1)
+	ok, err := bls12381.PairingCheck([]bls12381.G1Affine{g1Neg, pub}, []bls12381.G2Affine{sig, msg})
+	assert.NoError(err)
+	assert.True(ok)
+
+	witness := verifyCircuit{
+		PubKey:    sw_bls12381.NewG1Affine(pub),
+		Signature: sw_bls12381.NewG2Affine(sig),
+		Message:   sw_bls12381.NewG2Affine(msg),
+	}
+	err = test.IsSolved(&verifyCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the signature of another message
+	other, err := bls12381.HashToG2([]byte("another message"), dst)
+	assert.NoError(err)
+	witness.Message = sw_bls12381.NewG2Affine(other)
+	err = test.IsSolved(&verifyCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
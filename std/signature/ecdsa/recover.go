@@ -0,0 +1,24 @@
+package ecdsa
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/evmprecompiles"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// Recover returns the secp256k1 public key which signed the message msg with
+// the signature sig, given the recovery identifier v ∈ {0, 1, 2, 3} of the
+// point R of the signature: its parity is the lowest bit of v, and the highest
+// bit of v tells whether its x-coordinate overflows the scalar field. As
+// Ethereum's ecrecover, it asserts that R is on the curve and that the
+// recovered key verifies the signature.
+//
+// We assume that the message msg is already hashed to the scalar field. The
+// component S of the signature is only required to be less than the scalar
+// field modulus, see [evmprecompiles.ECRecover] for the stricter check on the
+// signatures of Ethereum transactions.
+func Recover(api frontend.API, msg *emulated.Element[emulated.Secp256k1Fr], sig *Signature[emulated.Secp256k1Fr], v frontend.Variable) *PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr] {
+	// ECRecover takes the identifier with the offset 27 used by the EVM.
+	p := evmprecompiles.ECRecover(api, *msg, api.Add(v, 27), sig.R, sig.S, 0)
+	return (*PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr])(p)
+}
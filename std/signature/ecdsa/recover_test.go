@@ -0,0 +1,64 @@
+package ecdsa
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type recoverCircuit struct {
+	Msg emulated.Element[emulated.Secp256k1Fr]
+	Sig Signature[emulated.Secp256k1Fr]
+	V   frontend.Variable
+	Pub PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]
+}
+
+func (c *recoverCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[emulated.Secp256k1Fp, emulated.Secp256k1Fr](api, sw_emulated.GetSecp256k1Params())
+	if err != nil {
+		return err
+	}
+	pub := Recover(api, &c.Msg, &c.Sig, c.V)
+	expected := sw_emulated.AffinePoint[emulated.Secp256k1Fp](c.Pub)
+	curve.AssertIsEqual((*sw_emulated.AffinePoint[emulated.Secp256k1Fp])(pub), &expected)
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	sk, err := ecdsa.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	msg := []byte("testing ECDSA public key recovery")
+	v, r, s, err := sk.SignForRecover(msg, nil)
+	assert.NoError(err)
+
+	// the native recovery
+	var pub ecdsa.PublicKey
+	assert.NoError(pub.RecoverFrom(msg, v, r, s))
+	assert.True(pub.Equal(&sk.PublicKey))
+
+	witness := recoverCircuit{
+		Msg: emulated.ValueOf[emulated.Secp256k1Fr](ecdsa.HashToInt(msg)),
+		Sig: Signature[emulated.Secp256k1Fr]{
+			R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		V: v,
+		Pub: PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](pub.A.X),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](pub.A.Y),
+		},
+	}
+	assert.NoError(test.IsSolved(&recoverCircuit{}, &witness, ecc.BN254.ScalarField()))
+
+	// the other parity recovers another key
+	witness.V = v ^ 1
+	assert.Error(test.IsSolved(&recoverCircuit{}, &witness, ecc.BN254.ScalarField()))
+}
@@ -56,6 +56,56 @@ type Signature struct {
 // Verify verifies an eddsa signature using MiMC hash function
 // cf https://en.wikipedia.org/wiki/EdDSA
 func Verify(curve twistededwards.Curve, sig Signature, msg frontend.Variable, pubKey PublicKey, hash hash.FieldHasher) error {
+	Q, err := verificationPoint(curve, sig, msg, pubKey, hash)
+	if err != nil {
+		return err
+	}
+
+	curve.API().AssertIsEqual(Q.X, 0)
+	curve.API().AssertIsEqual(Q.Y, 1)
+
+	return nil
+}
+
+// VerifyThreshold verifies that at least k of the holders of the public keys
+// pubs signed msg. present[i] is 1 if the i-th holder signed, in which case
+// sigs[i] is verified against pubs[i], and 0 otherwise, in which case sigs[i]
+// is ignored and may be any point and scalar.
+//
+// EdDSA signatures made independently by several signers do not aggregate:
+// each has its own nonce R, hashed with the key of the signer. So each present
+// signature is verified against its own key, rather than checking an aggregate
+// signature against the sum of the keys.
+func VerifyThreshold(curve twistededwards.Curve, sigs []Signature, pubs []PublicKey, present []frontend.Variable, msg frontend.Variable, hash hash.FieldHasher, k int) error {
+	if len(sigs) != len(pubs) || len(present) != len(pubs) {
+		return errors.New("there must be as many signatures and presence flags as public keys")
+	}
+	if k < 0 || k > len(pubs) {
+		return errors.New("threshold must be between 0 and the number of public keys")
+	}
+	api := curve.API()
+	count := frontend.Variable(0)
+	for i := range pubs {
+		api.AssertIsBoolean(present[i])
+		count = api.Add(count, present[i])
+
+		hash.Reset()
+		Q, err := verificationPoint(curve, sigs[i], msg, pubs[i], hash)
+		if err != nil {
+			return err
+		}
+		api.AssertIsEqual(api.Select(present[i], Q.X, 0), 0)
+		api.AssertIsEqual(api.Select(present[i], Q.Y, 1), 1)
+	}
+	// count-k wraps around the modulus when less than k signed
+	api.AssertIsLessOrEqual(api.Sub(count, k), len(pubs)-k)
+
+	return nil
+}
+
+// verificationPoint returns [cofactor]([S]G-[H(R,A,M)]*A-R), which is the
+// neutral element if and only if the signature is valid.
+func verificationPoint(curve twistededwards.Curve, sig Signature, msg frontend.Variable, pubKey PublicKey, hash hash.FieldHasher) (twistededwards.Point, error) {
 
 	// compute H(R, A, M)
 	hash.Write(sig.R.X)
@@ -83,7 +133,7 @@ func Verify(curve twistededwards.Curve, sig Signature, msg frontend.Variable, pu
 	if !curve.Params().Cofactor.IsUint64() {
 		err := errors.New("invalid cofactor")
 		log.Err(err).Str("cofactor", curve.Params().Cofactor.String()).Send()
-		return err
+		return twistededwards.Point{}, err
 	}
 	cofactor := curve.Params().Cofactor.Uint64()
 	switch cofactor {
@@ -95,10 +145,7 @@ func Verify(curve twistededwards.Curve, sig Signature, msg frontend.Variable, pu
 		log.Warn().Str("cofactor", curve.Params().Cofactor.String()).Msg("curve cofactor is not implemented")
 	}
 
-	curve.API().AssertIsEqual(Q.X, 0)
-	curve.API().AssertIsEqual(Q.Y, 1)
-
-	return nil
+	return Q, nil
 }
 
 // Assign is a helper to assigned a compressed binary public key representation into its uncompressed form
@@ -0,0 +1,95 @@
+package eddsa
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark-crypto/signature/eddsa"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type thresholdCircuit struct {
+	PublicKeys []PublicKey `gnark:",public"`
+	Signatures []Signature
+	Present    []frontend.Variable
+	Message    frontend.Variable `gnark:",public"`
+	k          int
+}
+
+func (circuit *thresholdCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	mimc, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	return VerifyThreshold(curve, circuit.Signatures, circuit.PublicKeys, circuit.Present, circuit.Message, &mimc, circuit.k)
+}
+
+func TestVerifyThreshold(t *testing.T) {
+	assert := test.NewAssert(t)
+	const n, k = 3, 2
+
+	msg, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	msgData := make([]byte, 32)
+	msg.FillBytes(msgData)
+
+	newCircuit := func() *thresholdCircuit {
+		return &thresholdCircuit{
+			PublicKeys: make([]PublicKey, n),
+			Signatures: make([]Signature, n),
+			Present:    make([]frontend.Variable, n),
+			k:          k,
+		}
+	}
+
+	// witness returns an assignment where the signers flagged in present signed
+	// msg, and the other ones assigned a signature of another message.
+	witness := func(present ...int) *thresholdCircuit {
+		w := newCircuit()
+		w.Message = msg
+		for i := 0; i < n; i++ {
+			privKey, err := eddsa.New(tedwards.BN254, rand.Reader)
+			assert.NoError(err)
+			w.PublicKeys[i].Assign(tedwards.BN254, privKey.Public().Bytes())
+			w.Present[i] = present[i]
+			toSign := msgData
+			if present[i] == 0 {
+				toSign = new(big.Int).Add(msg, big.NewInt(1)).FillBytes(make([]byte, 32))
+			}
+			sig, err := privKey.Sign(toSign, hash.MIMC_BN254.New())
+			assert.NoError(err)
+			w.Signatures[i].Assign(tedwards.BN254, sig)
+		}
+		return w
+	}
+
+	// a present signature of another message is invalid
+	invalid := witness(1, 1, 0)
+	invalid.Present[2] = 1
+	// an absent signature may be any point, even off the curve, and any scalar
+	offCurve := witness(0, 1, 1)
+	r, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	s, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	offCurve.Signatures[0] = Signature{R: twistededwards.Point{X: r, Y: 2}, S: s}
+	assert.CheckCircuit(newCircuit(),
+		test.WithValidAssignment(witness(1, 0, 1)),
+		test.WithValidAssignment(offCurve),
+		test.WithValidAssignment(witness(1, 1, 1)),
+		test.WithInvalidAssignment(witness(0, 1, 0)),
+		test.WithInvalidAssignment(witness(0, 0, 0)),
+		test.WithInvalidAssignment(invalid),
+		test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,103 @@
+/*
+Package rsa implements the verification of RSA PKCS #1 v1.5 signatures.
+
+The package depends on the [emulated] package for the arithmetic modulo the
+RSA modulus N, which is given as the modulus of the emulation parameters. The
+modulus of the parameters doesn't need to be prime, and is fixed when the
+circuit is compiled: a circuit verifies the signatures of a single key, as is
+the case for instance for the keys of an OpenID provider. For example, for a
+2048 bits modulus N:
+
+	type Key struct{}
+
+	func (Key) NbLimbs() uint     { return 32 }
+	func (Key) BitsPerLimb() uint { return 64 }
+	func (Key) IsPrime() bool     { return false }
+	func (Key) Modulus() *big.Int { return N }
+
+The signature is raised to the public exponent by square-and-multiply, which
+costs about 17 multiplications modulo N for the usual exponent 65537.
+
+See [RFC 8017] for the signature scheme.
+
+[RFC 8017]: https://www.rfc-editor.org/rfc/rfc8017#section-8.2
+*/
+package rsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// sha256Prefix is the DER encoding of the DigestInfo of a SHA-256 digest,
+// without the digest.
+var sha256Prefix = []byte{0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20}
+
+// PublicKey is an RSA public key whose modulus is the modulus of the
+// emulation parameters T, and E the public exponent.
+type PublicKey[T emulated.FieldParams] struct {
+	E int
+}
+
+// VerifyPKCS1v15 asserts that sig is a valid RSASSA-PKCS1-v1_5 signature of a
+// SHA-256 digest under pub, as rsa.VerifyPKCS1v15 with crypto.SHA256 does.
+// msgHash are the 32 bytes of the digest. They are constrained to be bytes, and
+// sig to be less than the modulus.
+//
+// The signature is verified by asserting that sig^E mod N is the padded
+// digest
+//
+//	0x00 ‖ 0x01 ‖ 0xff…0xff ‖ 0x00 ‖ DigestInfo ‖ msgHash.
+func VerifyPKCS1v15[T emulated.FieldParams](api frontend.API, pub PublicKey[T], msgHash []frontend.Variable, sig *emulated.Element[T]) {
+	if pub.E < 2 {
+		panic(fmt.Sprintf("invalid public exponent %d", pub.E))
+	}
+	if len(msgHash) != 32 {
+		panic(fmt.Sprintf("got a digest of %d bytes, SHA-256 digests have 32", len(msgHash)))
+	}
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		panic(err)
+	}
+	var params T
+	k := (params.Modulus().BitLen() + 7) / 8
+	tLen := len(sha256Prefix) + len(msgHash)
+	if k < tLen+11 {
+		panic(fmt.Sprintf("modulus of %d bytes is too short for PKCS #1 v1.5 signatures", k))
+	}
+
+	// the padded digest, as little-endian bits. The constant part is set on
+	// the most significant bytes, the digest on the least significant ones.
+	em := make([]byte, k)
+	em[1] = 0x01
+	for i := 2; i < k-tLen-1; i++ {
+		em[i] = 0xff
+	}
+	copy(em[k-tLen:], sha256Prefix)
+	emConst := new(big.Int).SetBytes(em)
+	emBits := make([]frontend.Variable, params.NbLimbs()*params.BitsPerLimb())
+	for i := range emBits {
+		emBits[i] = emConst.Bit(i)
+	}
+	for i := range msgHash {
+		b := bits.ToBinary(api, msgHash[len(msgHash)-1-i], bits.WithNbDigits(8))
+		copy(emBits[8*i:], b)
+	}
+	expected := f.FromBits(emBits...)
+
+	// sig^E by square-and-multiply, from the most significant bit of E
+	f.AssertIsInRange(sig)
+	e := big.NewInt(int64(pub.E))
+	res := sig
+	for i := e.BitLen() - 2; i >= 0; i-- {
+		res = f.Mul(res, res)
+		if e.Bit(i) == 1 {
+			res = f.Mul(res, sig)
+		}
+	}
+	f.AssertIsEqual(res, expected)
+}
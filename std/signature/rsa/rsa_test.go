@@ -0,0 +1,70 @@
+package rsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+// testKey is the key generated by the test, whose modulus is the one of
+// testParams.
+var testKey *rsa.PrivateKey
+
+type testParams struct{}
+
+func (testParams) NbLimbs() uint     { return 32 }
+func (testParams) BitsPerLimb() uint { return 64 }
+func (testParams) IsPrime() bool     { return false }
+func (testParams) Modulus() *big.Int { return testKey.N }
+
+type verifyCircuit struct {
+	MsgHash [32]frontend.Variable
+	Sig     emulated.Element[testParams]
+}
+
+func (c *verifyCircuit) Define(api frontend.API) error {
+	VerifyPKCS1v15(api, PublicKey[testParams]{E: testKey.E}, c.MsgHash[:], &c.Sig)
+	return nil
+}
+
+func TestVerifyPKCS1v15(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var err error
+	testKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(err)
+	digest := sha256.Sum256([]byte("gnark"))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, testKey, crypto.SHA256, digest[:])
+	assert.NoError(err)
+	assert.NoError(rsa.VerifyPKCS1v15(&testKey.PublicKey, crypto.SHA256, digest[:], sig))
+
+	newWitness := func(digest [32]byte, sig *big.Int) *verifyCircuit {
+		var w verifyCircuit
+		for i := range digest {
+			w.MsgHash[i] = digest[i]
+		}
+		w.Sig = emulated.ValueOf[testParams](sig)
+		return &w
+	}
+	sigInt := new(big.Int).SetBytes(sig)
+	err = test.IsSolved(&verifyCircuit{}, newWitness(digest, sigInt), ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// another digest
+	wrongDigest := digest
+	wrongDigest[31] ^= 1
+	err = test.IsSolved(&verifyCircuit{}, newWitness(wrongDigest, sigInt), ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// another signature
+	err = test.IsSolved(&verifyCircuit{}, newWitness(digest, new(big.Int).Add(sigInt, big.NewInt(1))), ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,50 @@
+// Package schnorr provides a ZKP-circuit function to verify a Schnorr
+// signature over a twisted Edwards curve.
+//
+// A signature of a message m under the public key P = [x]G is a pair (R, s)
+// where R = [k]G for a random nonce k and s = k + c·x mod l, with l the order
+// of the subgroup generated by G and c = H(R, P, m). It is valid if
+//
+//	[s]G == R + [c]P
+//
+// The challenge is the same as the one of EdDSA, but the verification equation
+// is checked as is, without multiplying it by the cofactor: R and P must be
+// in the subgroup generated by G for a valid signature to verify.
+package schnorr
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// Signature stores a Schnorr signature (to be used in gnark circuit): the
+// commitment to the nonce R and the scalar S.
+type Signature struct {
+	R twistededwards.Point
+	S frontend.Variable
+}
+
+// Verify verifies that sig is a Schnorr signature of msg under the public key
+// pub, with the challenge c = h(R.X, R.Y, pub.X, pub.Y, msg).
+func Verify(curve twistededwards.Curve, sig Signature, pub twistededwards.Point, msg frontend.Variable, h hash.FieldHasher) error {
+	api := curve.API()
+
+	h.Reset()
+	h.Write(sig.R.X, sig.R.Y, pub.X, pub.Y, msg)
+	c := h.Sum()
+
+	base := twistededwards.Point{
+		X: curve.Params().Base[0],
+		Y: curve.Params().Base[1],
+	}
+	curve.AssertIsOnCurve(sig.R)
+	curve.AssertIsOnCurve(pub)
+
+	// [s]G - [c]P == R
+	Q := curve.DoubleBaseScalarMul(base, curve.Neg(pub), sig.S, c)
+	api.AssertIsEqual(Q.X, sig.R.X)
+	api.AssertIsEqual(Q.Y, sig.R.Y)
+
+	return nil
+}
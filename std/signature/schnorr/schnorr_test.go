@@ -0,0 +1,106 @@
+package schnorr
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	edwardsbn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	tedwards "github.com/consensys/gnark-crypto/ecc/twistededwards"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/native/twistededwards"
+	stdmimc "github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type schnorrCircuit struct {
+	PublicKey twistededwards.Point `gnark:",public"`
+	Signature Signature
+	Message   frontend.Variable `gnark:",public"`
+}
+
+func (circuit *schnorrCircuit) Define(api frontend.API) error {
+	curve, err := twistededwards.NewEdCurve(api, tedwards.BN254)
+	if err != nil {
+		return err
+	}
+	h, err := stdmimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	return Verify(curve, circuit.Signature, circuit.PublicKey, circuit.Message, &h)
+}
+
+// sign returns the public key of a random secret key, and a signature of msg
+// under this key.
+func sign(t *testing.T, msg *fr.Element) (pub, r edwardsbn254.PointAffine, s *big.Int) {
+	params := edwardsbn254.GetEdwardsCurve()
+	x, err := rand.Int(rand.Reader, &params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := rand.Int(rand.Reader, &params.Order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub.ScalarMultiplication(&params.Base, x)
+	r.ScalarMultiplication(&params.Base, k)
+
+	// c = H(R, P, m)
+	h := mimc.NewMiMC()
+	for _, v := range []*fr.Element{&r.X, &r.Y, &pub.X, &pub.Y, msg} {
+		b := v.Bytes()
+		h.Write(b[:])
+	}
+	c := new(big.Int).SetBytes(h.Sum(nil))
+
+	// s = k + c·x mod l
+	s = new(big.Int).Mul(c, x)
+	s.Add(s, k).Mod(s, &params.Order)
+
+	// native check: [s]G == R + [c]P
+	var lhs, rhs, cP edwardsbn254.PointAffine
+	lhs.ScalarMultiplication(&params.Base, s)
+	cP.ScalarMultiplication(&pub, c)
+	rhs.Add(&r, &cP)
+	if !lhs.Equal(&rhs) {
+		t.Fatal("native verification failed")
+	}
+	return
+}
+
+func TestSchnorr(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	var msg fr.Element
+	msg.SetRandom()
+	pub, r, s := sign(t, &msg)
+
+	witness := schnorrCircuit{
+		PublicKey: twistededwards.Point{X: pub.X, Y: pub.Y},
+		Signature: Signature{R: twistededwards.Point{X: r.X, Y: r.Y}, S: s},
+		Message:   msg,
+	}
+	assert.CheckCircuit(&schnorrCircuit{}, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+
+	// tampered message
+	tampered := witness
+	var otherMsg fr.Element
+	otherMsg.Add(&msg, new(fr.Element).SetOne())
+	tampered.Message = otherMsg
+	assert.CheckCircuit(&schnorrCircuit{}, test.WithInvalidAssignment(&tampered), test.WithCurves(ecc.BN254))
+
+	// tampered scalar
+	tampered = witness
+	tampered.Signature.S = new(big.Int).Add(s, big.NewInt(1))
+	assert.CheckCircuit(&schnorrCircuit{}, test.WithInvalidAssignment(&tampered), test.WithCurves(ecc.BN254))
+
+	// signature under another key
+	otherPub, _, _ := sign(t, &msg)
+	tampered = witness
+	tampered.PublicKey = twistededwards.Point{X: otherPub.X, Y: otherPub.Y}
+	assert.CheckCircuit(&schnorrCircuit{}, test.WithInvalidAssignment(&tampered), test.WithCurves(ecc.BN254))
+}
@@ -0,0 +1,45 @@
+// Package sort implements gadgets to check sorted lists of variables, as used
+// by memory-consistency arguments where the accesses are sorted by address and
+// timestamp.
+package sort
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/set"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// AssertIsSortOf asserts that sorted is input sorted in non-decreasing order,
+// that is that sorted is non-decreasing and is a permutation of input. The
+// values must be less than 2^bitLen. It panics if the lists have different
+// lengths, or if bitLen is too large for the differences of the values to be
+// compared in the native field.
+//
+// Each value of sorted and each difference sorted[i+1] - sorted[i] are range
+// checked on bitLen bits, and the permutation is checked with
+// [set.AssertIsPermutation]. Then the values of input are less than 2^bitLen
+// as well. Composite keys, such as an address and a timestamp, are sorted as
+// the single value address⋅2^(timestamp bits) + timestamp.
+func AssertIsSortOf(api frontend.API, input, sorted []frontend.Variable, bitLen int) {
+	if len(input) != len(sorted) {
+		panic(fmt.Sprintf("lists of different lengths %d and %d", len(input), len(sorted)))
+	}
+	if bitLen <= 0 || bitLen >= api.Compiler().Field().BitLen()-1 {
+		panic(fmt.Sprintf("invalid bit length %d", bitLen))
+	}
+	if len(sorted) == 0 {
+		return
+	}
+	rchecker := rangecheck.New(api)
+	for i := range sorted {
+		rchecker.Check(sorted[i], bitLen)
+		if i > 0 {
+			// both values are in [0, 2^bitLen), so the difference is in
+			// [0, 2^bitLen) exactly when sorted[i] >= sorted[i-1].
+			rchecker.Check(api.Sub(sorted[i], sorted[i-1]), bitLen)
+		}
+	}
+	set.AssertIsPermutation(api, input, sorted)
+}
@@ -0,0 +1,57 @@
+package sort
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type sortCircuit struct {
+	Input, Sorted []frontend.Variable
+	bitLen        int
+}
+
+func (c *sortCircuit) Define(api frontend.API) error {
+	AssertIsSortOf(api, c.Input, c.Sorted, c.bitLen)
+	return nil
+}
+
+func TestAssertIsSortOf(t *testing.T) {
+	assert := test.NewAssert(t)
+
+	input := []frontend.Variable{12, 3, 255, 3, 0, 40}
+	circuit := &sortCircuit{
+		Input:  make([]frontend.Variable, len(input)),
+		Sorted: make([]frontend.Variable, len(input)),
+		bitLen: 8,
+	}
+	assert.CheckCircuit(circuit,
+		test.WithValidAssignment(&sortCircuit{
+			Input:  input,
+			Sorted: []frontend.Variable{0, 3, 3, 12, 40, 255},
+		}),
+		// not sorted
+		test.WithInvalidAssignment(&sortCircuit{
+			Input:  input,
+			Sorted: []frontend.Variable{0, 3, 12, 3, 40, 255},
+		}),
+		// sorted, but not a permutation of the input
+		test.WithInvalidAssignment(&sortCircuit{
+			Input:  input,
+			Sorted: []frontend.Variable{0, 3, 12, 12, 40, 255},
+		}),
+		// sorted as field elements wrapping around the modulus
+		test.WithInvalidAssignment(&sortCircuit{
+			Input:  []frontend.Variable{-1, 3, 255, 3, 0, 40},
+			Sorted: []frontend.Variable{-1, 0, 3, 3, 40, 255},
+		}),
+		// values out of range
+		test.WithInvalidAssignment(&sortCircuit{
+			Input:  []frontend.Variable{12, 3, 256, 3, 0, 40},
+			Sorted: []frontend.Variable{0, 3, 3, 12, 40, 256},
+		}),
+		test.WithCurves(ecc.BN254),
+	)
+}